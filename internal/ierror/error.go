@@ -31,10 +31,12 @@ func IsTaggedFunc(static ...error) func(error, ...error) bool {
 // =============================================================================
 
 var (
-	ErrDec  = errors.New("ukdec error")
-	ErrExec = errors.New("ukexec error")
-	ErrInit = errors.New("ukinit error")
-	ErrSpec = errors.New("ukspec error")
+	ErrDec   = errors.New("ukdec error")
+	ErrExec  = errors.New("ukexec error")
+	ErrInit  = errors.New("ukinit error")
+	ErrSpec  = errors.New("ukspec error")
+	ErrSrc   = errors.New("uksrc error")
+	ErrValid = errors.New("ukvalid error")
 )
 
 // =============================================================================
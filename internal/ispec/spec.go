@@ -1,9 +1,18 @@
 package ispec
 
+import "unicode/utf8"
+
 const (
 	TagKeyArguments = "ukarg"
+	TagKeyDefault   = "ukdefault"
+	TagKeyEnv       = "ukenv"
 	TagKeyFlag      = "ukflag"
+	TagKeyHidden    = "ukhide"
 	TagKeyInline    = "ukinline"
+	TagKeyRepeat    = "ukrepeat"
+	TagKeyRequired  = "ukreq"
+	TagKeySplit     = "uksplit"
+	TagKeyValid     = "ukvalid"
 )
 
 func ConsumableSet(valid ...string) func(string) bool {
@@ -14,3 +23,11 @@ func ConsumableSet(valid ...string) func(string) bool {
 
 	return func(s string) (ok bool) { _, ok = set[s]; return }
 }
+
+// Label a flag name as given on the command line, single rune names are short
+func LabelFlag(name string) string {
+	if utf8.RuneCountInString(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
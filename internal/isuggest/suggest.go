@@ -0,0 +1,77 @@
+package isuggest
+
+import (
+	"cmp"
+	"slices"
+	"unicode/utf8"
+)
+
+// Closest returns the candidates within a small edit distance of target,
+// ordered by distance and then lexicographically.
+func Closest(target string, candidates []string) []string {
+	type scored struct {
+		value    string
+		distance int
+	}
+
+	limit := max(1, utf8.RuneCountInString(target)/3)
+
+	var list []scored
+	for _, candidate := range candidates {
+		if d := Distance(target, candidate); d <= limit {
+			list = append(list, scored{value: candidate, distance: d})
+		}
+	}
+
+	slices.SortFunc(list, func(a, b scored) int {
+		if c := cmp.Compare(a.distance, b.distance); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.value, b.value)
+	})
+
+	var result []string
+	for _, item := range list {
+		result = append(result, item.value)
+	}
+
+	return slices.Compact(result)
+}
+
+// Distance computes the optimal string alignment distance between a and b,
+// ie. the Levenshtein distance extended with adjacent transpositions.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	// d[i][j] ⇒ distance between ra[:i] and rb[:j]
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(
+				d[i-1][j]+1,      // deletion
+				d[i][j-1]+1,      // insertion
+				d[i-1][j-1]+cost, // substitution
+			)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1) // transposition
+			}
+		}
+	}
+
+	return d[len(ra)][len(rb)]
+}
@@ -8,8 +8,11 @@ import (
 
 	"github.com/oligarch316/ukase/internal/ilog"
 	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukmeta/ukcomp"
+	"github.com/oligarch316/ukase/ukmeta/ukdoc"
 	"github.com/oligarch316/ukase/ukmeta/ukgen"
 	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+	"github.com/oligarch316/ukase/ukmeta/ukman"
 )
 
 // =============================================================================
@@ -19,10 +22,16 @@ import (
 var cfgDefault = Config{
 	Log:            ilog.Discard,
 	HelpCommand:    "help",
+	CompCommand:    "",
+	ManCommand:     "",
+	DocCommand:     "",
 	InputProgram:   os.Args[0],
 	InputArguments: os.Args[1:],
 	CLI:            nil,
 	Help:           nil,
+	Comp:           nil,
+	Man:            nil,
+	Doc:            nil,
 	Gen:            nil,
 }
 
@@ -35,6 +44,15 @@ type Config struct {
 	// TODO: Document
 	HelpCommand string
 
+	// TODO: Document
+	CompCommand string
+
+	// TODO: Document
+	ManCommand string
+
+	// TODO: Document
+	DocCommand string
+
 	// TODO: Document
 	InputProgram string
 
@@ -47,6 +65,15 @@ type Config struct {
 	// TODO: Document
 	Help []ukhelp.Option
 
+	// TODO: Document
+	Comp []ukcomp.Option
+
+	// TODO: Document
+	Man []ukman.Option
+
+	// TODO: Document
+	Doc []ukdoc.Option
+
 	// TODO: Document
 	Gen []ukgen.Option
 }
@@ -118,6 +145,26 @@ func (c Command[Params]) Bind(target ...string) ukcli.Directive {
 	})
 }
 
+// =============================================================================
+// Directive› Hidden
+// =============================================================================
+
+func NewHidden() ukcli.Hidden {
+	return ukcli.NewHidden()
+}
+
+// =============================================================================
+// Directive› Alias
+// =============================================================================
+
+func NewAlias(names ...string) ukcli.Alias {
+	return ukcli.NewAlias(names...)
+}
+
+func NewDeprecatedAlias(names ...string) ukcli.Alias {
+	return ukcli.NewDeprecatedAlias(names...)
+}
+
 // =============================================================================
 // Directive› Rule
 // =============================================================================
@@ -125,3 +172,11 @@ func (c Command[Params]) Bind(target ...string) ukcli.Directive {
 func NewRule[Params any](rule func(*Params)) ukcli.Rule[Params] {
 	return ukcli.NewRule(rule)
 }
+
+// =============================================================================
+// Directive› Validation
+// =============================================================================
+
+func NewValidation[Params any](validation func(*Params) error) ukcli.Validation[Params] {
+	return ukcli.NewValidation(validation)
+}
@@ -4,13 +4,19 @@ package ukase
 
 import (
 	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukmeta/ukcomp"
+	"github.com/oligarch316/ukase/ukmeta/ukdoc"
 	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+	"github.com/oligarch316/ukase/ukmeta/ukman"
 )
 
 type appConfig struct{ Config }
 
 func (ac appConfig) UkaseApplyCLI(c *ukcli.Config) {
 	ac.cliApplyHelpAuto(c)
+	ac.cliApplyCompAuto(c)
+	ac.cliApplyManAuto(c)
+	ac.cliApplyDocAuto(c)
 	ac.cliApplyUser(c)
 }
 
@@ -21,6 +27,39 @@ func (ac appConfig) cliApplyHelpAuto(c *ukcli.Config) {
 	c.Middleware = append(c.Middleware, helpAuto)
 }
 
+func (ac appConfig) cliApplyCompAuto(c *ukcli.Config) {
+	if ac.CompCommand == "" {
+		return
+	}
+
+	compBuilder := ukcomp.NewBuilder(ac.Comp...)
+	compAuto := compBuilder.Auto(ac.CompCommand)
+
+	c.Middleware = append(c.Middleware, compAuto)
+}
+
+func (ac appConfig) cliApplyManAuto(c *ukcli.Config) {
+	if ac.ManCommand == "" {
+		return
+	}
+
+	manBuilder := ukman.NewBuilder(ac.Man...)
+	manAuto := manBuilder.Auto(ac.ManCommand)
+
+	c.Middleware = append(c.Middleware, manAuto)
+}
+
+func (ac appConfig) cliApplyDocAuto(c *ukcli.Config) {
+	if ac.DocCommand == "" {
+		return
+	}
+
+	docBuilder := ukdoc.NewBuilder(ac.Doc...)
+	docAuto := docBuilder.Auto(ac.DocCommand)
+
+	c.Middleware = append(c.Middleware, docAuto)
+}
+
 func (ac appConfig) cliApplyUser(c *ukcli.Config) {
 	for _, opt := range ac.CLI {
 		opt.UkaseApplyCLI(c)
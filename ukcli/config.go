@@ -8,6 +8,8 @@ import (
 	"github.com/oligarch316/ukase/ukcore/ukexec"
 	"github.com/oligarch316/ukase/ukcore/ukinit"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
+	"github.com/oligarch316/ukase/ukcore/uksrc"
+	"github.com/oligarch316/ukase/ukcore/ukvalid"
 )
 
 // =============================================================================
@@ -32,6 +34,12 @@ type Config struct {
 	// TODO: Document
 	Spec []ukspec.Option
 
+	// TODO: Document
+	Source []uksrc.Source
+
+	// TODO: Document
+	Valid []ukvalid.Option
+
 	// TODO: Document
 	Middleware []func(State) State
 }
@@ -54,5 +62,7 @@ var cfgDefault = Config{
 	Decode:     nil,
 	Init:       nil,
 	Spec:       nil,
+	Source:     nil,
+	Valid:      nil,
 	Middleware: nil,
 }
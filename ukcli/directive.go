@@ -2,10 +2,12 @@ package ukcli
 
 import (
 	"context"
+	"errors"
 	"reflect"
 
 	"github.com/oligarch316/ukase/ukcore"
 	"github.com/oligarch316/ukase/ukcore/ukinit"
+	"github.com/oligarch316/ukase/ukcore/ukvalid"
 )
 
 var directiveNoop directiveFunc = func(State) error { return nil }
@@ -37,6 +39,21 @@ func (r Rule[Params]) UkaseRegister(state State) error {
 	return nil
 }
 
+// =============================================================================
+// Validation
+// =============================================================================
+
+type Validation[Params any] func(*Params) error
+
+func NewValidation[Params any](validation func(*Params) error) Validation[Params] {
+	return Validation[Params](validation)
+}
+
+func (v Validation[Params]) UkaseRegister(state State) error {
+	state.RegisterValidation(ukvalid.NewRule(v))
+	return nil
+}
+
 // =============================================================================
 // Info
 // =============================================================================
@@ -52,6 +69,67 @@ func (i Info) Bind(target ...string) Directive {
 	return directiveFunc(dir)
 }
 
+// =============================================================================
+// Hidden
+// =============================================================================
+
+type Hidden struct{}
+
+func NewHidden() Hidden { return Hidden{} }
+
+func (Hidden) Bind(target ...string) Directive {
+	dir := func(s State) error { return s.RegisterHidden(target...) }
+	return directiveFunc(dir)
+}
+
+// =============================================================================
+// Meta
+// =============================================================================
+
+type Meta struct{}
+
+func NewMeta() Meta { return Meta{} }
+
+func (Meta) Bind(target ...string) Directive {
+	dir := func(s State) error { return s.RegisterMeta(target...) }
+	return directiveFunc(dir)
+}
+
+// =============================================================================
+// Alias
+// =============================================================================
+
+type Alias struct {
+	Names      []string
+	Deprecated bool
+}
+
+func NewAlias(names ...string) Alias {
+	return Alias{Names: names}
+}
+
+func NewDeprecatedAlias(names ...string) Alias {
+	return Alias{Names: names, Deprecated: true}
+}
+
+func (a Alias) Bind(target ...string) Directive {
+	dir := func(s State) error {
+		register := s.RegisterAlias
+		if a.Deprecated {
+			register = s.RegisterDeprecated
+		}
+
+		var errs []error
+		for _, name := range a.Names {
+			errs = append(errs, register(name, target...))
+		}
+
+		return errors.Join(errs...)
+	}
+
+	return directiveFunc(dir)
+}
+
 // =============================================================================
 // Exec
 // =============================================================================
@@ -104,13 +182,56 @@ func (h Handler[Params]) Bind(target ...string) Directive {
 func (h Handler[Params]) exec(ctx context.Context, in Input) error {
 	var params Params
 
-	if err := in.Initialize(&params); err != nil {
+	if err := loadParams(in, &params); err != nil {
 		return err
 	}
 
-	if err := in.Decode(&params); err != nil {
+	return h(ctx, params)
+}
+
+// =============================================================================
+// InputHandler
+// =============================================================================
+
+// InputHandler is a Handler variant receiving the input alongside a pointer to
+// the loaded parameters, eg. to query `in.IsSet(&params.Field)`
+type InputHandler[Params any] func(context.Context, Input, *Params) error
+
+func NewInputHandler[Params any](handler func(context.Context, Input, *Params) error) InputHandler[Params] {
+	return InputHandler[Params](handler)
+}
+
+func (h InputHandler[Params]) Bind(target ...string) Directive {
+	if h == nil {
+		return directiveNoop
+	}
+
+	exec := Exec[Params](h.exec)
+	return exec.Bind(target...)
+}
+
+func (h InputHandler[Params]) exec(ctx context.Context, in Input) error {
+	params := new(Params)
+
+	if err := loadParams(in, params); err != nil {
 		return err
 	}
 
-	return h(ctx, params)
+	return h(ctx, in, params)
+}
+
+func loadParams(in Input, params any) error {
+	if err := in.Initialize(params); err != nil {
+		return err
+	}
+
+	if err := in.Source(params); err != nil {
+		return err
+	}
+
+	if err := in.Decode(params); err != nil {
+		return err
+	}
+
+	return in.Validate(params)
 }
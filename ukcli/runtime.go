@@ -3,12 +3,14 @@ package ukcli
 import (
 	"context"
 	"reflect"
+	"slices"
 
 	"github.com/oligarch316/ukase/ukcore"
 	"github.com/oligarch316/ukase/ukcore/ukdec"
 	"github.com/oligarch316/ukase/ukcore/ukexec"
 	"github.com/oligarch316/ukase/ukcore/ukinit"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
+	"github.com/oligarch316/ukase/ukcore/ukvalid"
 )
 
 // =============================================================================
@@ -61,31 +63,45 @@ var _ State = (*state)(nil)
 
 type State interface {
 	// Execution time utilities
+	loadCompletion(values []string) (ukexec.Completion, error)
 	loadMeta(target []string) (ukexec.Meta, error)
 	loadSpec(t reflect.Type) (ukspec.Parameters, error)
-	runDecode(ukcore.Input, any) error
+	runDecode(ukcore.Input, ukdec.FieldSet, any) (decodeResult, error)
 	runInit(any) error
+	runSource(ukcore.Input, any) (decodeResult, error)
+	runValid(ukcore.Input, ukdec.FieldSet, any) error
 
 	// Registration time utilities
+	RegisterAlias(name string, target ...string) error
+	RegisterDeprecated(name string, target ...string) error
 	RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...string) error
+	RegisterHidden(target ...string) error
 	RegisterInfo(info any, target ...string) error
+	RegisterMeta(target ...string) error
 	RegisterRule(rule ukinit.Rule)
+	RegisterValidation(rule ukvalid.Rule)
 }
 
 type state struct {
-	config  Config
-	execMux *ukexec.Mux
-	ruleSet *ukinit.RuleSet
+	config   Config
+	execMux  *ukexec.Mux
+	ruleSet  *ukinit.RuleSet
+	validSet *ukvalid.RuleSet
 }
 
 func newState(config Config) *state {
 	return &state{
-		config:  config,
-		execMux: ukexec.New(config.Exec...),
-		ruleSet: ukinit.NewRuleSet(config.Init...),
+		config:   config,
+		execMux:  ukexec.New(config.Exec...),
+		ruleSet:  ukinit.NewRuleSet(config.Init...),
+		validSet: ukvalid.NewRuleSet(config.Valid...),
 	}
 }
 
+func (s *state) loadCompletion(values []string) (ukexec.Completion, error) {
+	return s.execMux.Complete(values)
+}
+
 func (s *state) loadMeta(target []string) (ukexec.Meta, error) {
 	return s.execMux.Meta(target...)
 }
@@ -94,9 +110,18 @@ func (s *state) loadSpec(t reflect.Type) (ukspec.Parameters, error) {
 	return ukspec.NewParameters(t, s.config.Spec...)
 }
 
-func (s *state) runDecode(i ukcore.Input, v any) error {
-	decoder := ukdec.NewDecoder(i, s.config.Decode...)
-	return decoder.Decode(v)
+func (s *state) runDecode(i ukcore.Input, set ukdec.FieldSet, v any) (decodeResult, error) {
+	var result decodeResult
+
+	// Environment and flag values sit above those already set (by sources)
+	// ⇒ Replace rather than merge with slice and map values
+	decodeOpts := append(slices.Clip(s.config.Decode), replaceDecodeOption(set))
+
+	decoder := ukdec.NewDecoder(i, decodeOpts...)
+	err := decoder.Decode(v)
+
+	result.insert(decoder)
+	return result, err
 }
 
 func (s *state) runInit(v any) error {
@@ -108,18 +133,118 @@ func (s *state) runInit(v any) error {
 	return s.ruleSet.Process(spec, v)
 }
 
+func (s *state) runSource(i ukcore.Input, v any) (decodeResult, error) {
+	var result decodeResult
+
+	if len(s.config.Source) == 0 {
+		return result, nil
+	}
+
+	spec, err := ukspec.ParametersOf(v, s.config.Spec...)
+	if err != nil {
+		return result, err
+	}
+
+	// Source values sit beneath the environment
+	// ⇒ Leave environment lookup to the subsequent `runDecode`
+	decodeOpts := append(slices.Clip(s.config.Decode), sourceDecodeOption{})
+
+	for _, source := range s.config.Source {
+		flags, err := source.UkaseSource(spec)
+		if err != nil {
+			return result, err
+		}
+
+		// Each source sits above those before it
+		// ⇒ Replace rather than merge with values already set
+		sourceOpts := append(slices.Clip(decodeOpts), replaceDecodeOption(result.Set))
+
+		sourceInput := ukcore.Input{Program: i.Program, Target: i.Target, Flags: flags}
+		decoder := ukdec.NewDecoder(sourceInput, sourceOpts...)
+
+		err = decoder.Decode(v)
+		result.insert(decoder)
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (s *state) runValid(i ukcore.Input, set ukdec.FieldSet, v any) error {
+	spec, err := ukspec.ParametersOf(v, s.config.Spec...)
+	if err != nil {
+		return err
+	}
+
+	return s.validSet.Process(i, set, spec, v)
+}
+
+// Decode side effects, accumulated per parameters value across decoders
+type decodeResult struct {
+	Set      ukdec.FieldSet
+	Warnings []ukdec.Warning
+}
+
+func (dr *decodeResult) insert(decoder *ukdec.Decoder) {
+	for _, index := range decoder.SetFields() {
+		if !dr.Set.Contains(index) {
+			dr.Set = append(dr.Set, index)
+		}
+	}
+
+	dr.Warnings = append(dr.Warnings, decoder.Warnings()...)
+}
+
+type sourceDecodeOption struct{}
+
+func (sourceDecodeOption) UkaseApplyDec(c *ukdec.Config) {
+	c.EnvLookup = func(string) (string, bool) { return "", false }
+
+	// Sources are partial by nature
+	// ⇒ Leave required field and group checks to the subsequent `runDecode`
+	c.CheckRequired = false
+	c.CheckGroups = false
+}
+
+type replaceDecodeOption ukdec.FieldSet
+
+func (o replaceDecodeOption) UkaseApplyDec(c *ukdec.Config) { c.Replace = ukdec.FieldSet(o) }
+
+func (s *state) RegisterAlias(name string, target ...string) error {
+	return s.execMux.RegisterAlias(name, target...)
+}
+
+func (s *state) RegisterDeprecated(name string, target ...string) error {
+	return s.execMux.RegisterDeprecated(name, target...)
+}
+
 func (s *state) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...string) error {
 	return s.execMux.RegisterExec(exec, spec, target...)
 }
 
+func (s *state) RegisterHidden(target ...string) error {
+	return s.execMux.RegisterHidden(target...)
+}
+
 func (s *state) RegisterInfo(info any, target ...string) error {
 	return s.execMux.RegisterInfo(info, target...)
 }
 
+func (s *state) RegisterMeta(target ...string) error {
+	return s.execMux.RegisterMeta(target...)
+}
+
 func (s *state) RegisterRule(rule ukinit.Rule) {
 	rule.Register(s.ruleSet)
 }
 
+func (s *state) RegisterValidation(rule ukvalid.Rule) {
+	rule.Register(s.validSet)
+}
+
 // =============================================================================
 // Input
 // =============================================================================
@@ -127,22 +252,121 @@ func (s *state) RegisterRule(rule ukinit.Rule) {
 var _ Input = input{}
 
 type Input interface {
+	Complete(values ...string) (ukexec.Completion, error)
 	Core() ukcore.Input
 	Decode(any) error
 	Initialize(any) error
 	Lookup(target ...string) (ukexec.Meta, error)
+	Source(any) error
+	Validate(any) error
+	Warnings() []ukdec.Warning
+
+	// IsSet reports whether the field referenced by fieldPtr, within
+	// parameters previously passed to Decode or Source, was assigned a value
+	// from a flag, argument, environment variable or source
+	IsSet(fieldPtr any) bool
 }
 
 type input struct {
-	core  ukcore.Input
-	state State
+	core    ukcore.Input
+	state   State
+	results *[]inputResult
+}
+
+type inputResult struct {
+	params reflect.Value
+	decodeResult
 }
 
 func newInput(core ukcore.Input, state State) input {
-	return input{core: core, state: state}
+	return input{core: core, state: state, results: new([]inputResult)}
+}
+
+func (i input) Complete(v ...string) (ukexec.Completion, error) { return i.state.loadCompletion(v) }
+func (i input) Core() ukcore.Input                              { return i.core }
+func (i input) Initialize(v any) error                          { return i.state.runInit(v) }
+func (i input) Lookup(t ...string) (ukexec.Meta, error)         { return i.state.loadMeta(t) }
+
+func (i input) Decode(v any) error {
+	result, err := i.state.runDecode(i.core, i.setFields(v), v)
+	i.record(v, result)
+	return err
+}
+
+func (i input) Source(v any) error {
+	result, err := i.state.runSource(i.core, v)
+	i.record(v, result)
+	return err
+}
+
+// Validate checks only those fields set by prior calls to Decode or Source,
+// or declaring a default
+func (i input) Validate(v any) error {
+	return i.state.runValid(i.core, i.setFields(v), v)
+}
+
+func (i input) Warnings() []ukdec.Warning {
+	var warnings []ukdec.Warning
+	for _, result := range *i.results {
+		warnings = append(warnings, result.Warnings...)
+	}
+	return warnings
 }
 
-func (i input) Core() ukcore.Input                      { return i.core }
-func (i input) Decode(v any) error                      { return i.state.runDecode(i.core, v) }
-func (i input) Initialize(v any) error                  { return i.state.runInit(v) }
-func (i input) Lookup(t ...string) (ukexec.Meta, error) { return i.state.loadMeta(t) }
+func (i input) IsSet(fieldPtr any) bool {
+	ptrVal := reflect.ValueOf(fieldPtr)
+	if ptrVal.Kind() != reflect.Pointer || ptrVal.IsNil() {
+		return false
+	}
+
+	for _, result := range *i.results {
+		for _, index := range result.Set {
+			// Compare type as well as address
+			// ⇒ A struct and its 1st field share an address
+			fieldVal, err := result.params.FieldByIndexErr(index)
+			if err != nil || fieldVal.Type() != ptrVal.Type().Elem() {
+				continue
+			}
+
+			if fieldVal.Addr().Pointer() == ptrVal.Pointer() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Fields of v set by prior calls to Decode or Source
+func (i input) setFields(v any) ukdec.FieldSet {
+	var set ukdec.FieldSet
+
+	paramsVal, ok := recordValue(v)
+	if !ok {
+		return set
+	}
+
+	for _, result := range *i.results {
+		if result.params.Type() == paramsVal.Type() && result.params.Addr().Pointer() == paramsVal.Addr().Pointer() {
+			set = append(set, result.Set...)
+		}
+	}
+
+	return set
+}
+
+func (i input) record(v any, result decodeResult) {
+	if paramsVal, ok := recordValue(v); ok {
+		*i.results = append(*i.results, inputResult{params: paramsVal, decodeResult: result})
+	}
+}
+
+func recordValue(v any) (reflect.Value, bool) {
+	paramsVal := reflect.ValueOf(v)
+	for paramsVal.Kind() == reflect.Pointer && !paramsVal.IsNil() {
+		paramsVal = paramsVal.Elem()
+	}
+
+	// Only addressable struct values yield meaningful field pointers
+	return paramsVal, paramsVal.Kind() == reflect.Struct && paramsVal.CanAddr()
+}
@@ -0,0 +1,119 @@
+package ukcli_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oligarch316/ukase/internal/itest"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcore/ukdec"
+	"github.com/oligarch316/ukase/ukcore/uksrc"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type cliOption func(*ukcli.Config)
+
+func (o cliOption) UkaseApplyCLI(c *ukcli.Config) { o(c) }
+
+type decOption func(*ukdec.Config)
+
+func (o decOption) UkaseApplyDec(c *ukdec.Config) { o(c) }
+
+func withEnv(env map[string]string) ukcli.Option {
+	lookup := func(name string) (value string, exists bool) { value, exists = env[name]; return }
+	dec := decOption(func(c *ukdec.Config) { c.EnvLookup = lookup })
+	return cliOption(func(c *ukcli.Config) { c.Decode = append(c.Decode, dec) })
+}
+
+func withSource(sources ...uksrc.Source) ukcli.Option {
+	return cliOption(func(c *ukcli.Config) { c.Source = append(c.Source, sources...) })
+}
+
+// Execute the given values against a single handler, returning the
+// parameters it received
+func runParams[Params any](values []string, opts ...ukcli.Option) (Params, error) {
+	var actual Params
+
+	handler := func(_ context.Context, params Params) error { actual = params; return nil }
+
+	runtime := ukcli.NewRuntime(opts...)
+	runtime.Add(ukcli.NewHandler(handler).Bind())
+
+	err := runtime.Execute(context.Background(), append([]string{"prog"}, values...))
+	return actual, err
+}
+
+// =============================================================================
+// Layers
+// =============================================================================
+
+func TestLayerPrecedence(t *testing.T) {
+	// Layer sources, environment and flags
+	// • Expect› Each layer replaces slice and map values of those beneath
+
+	type Params struct {
+		Hosts  []string          `ukflag:"hosts" ukenv:""`
+		Labels map[string]string `ukflag:"labels" ukenv:""`
+	}
+
+	type subtest struct {
+		name     string
+		values   []string
+		env      map[string]string
+		sources  []uksrc.Source
+		expected Params
+	}
+
+	fileA := uksrc.Map{"hosts": []any{"f1", "f2"}, "labels": map[string]any{"a": "1", "b": "2"}}
+	fileB := uksrc.Map{"hosts": []any{"g1"}, "labels": map[string]any{"c": "3"}}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual, err := runParams[Params](st.values, withEnv(st.env), withSource(st.sources...))
+		return st.name, itest.CmpSequence(cmp.Nil(err), cmp.DeepEqual(actual, st.expected))
+	}
+
+	subtests := []subtest{
+		{
+			name:     "file",
+			sources:  []uksrc.Source{fileA},
+			expected: Params{Hosts: []string{"f1", "f2"}, Labels: map[string]string{"a": "1", "b": "2"}},
+		},
+		{
+			name:     "file over file",
+			sources:  []uksrc.Source{fileA, fileB},
+			expected: Params{Hosts: []string{"g1"}, Labels: map[string]string{"c": "3"}},
+		},
+		{
+			name:     "env over file",
+			env:      map[string]string{"HOSTS": "envh", "LABELS": "d=4"},
+			sources:  []uksrc.Source{fileA},
+			expected: Params{Hosts: []string{"envh"}, Labels: map[string]string{"d": "4"}},
+		},
+		{
+			name:     "flags over file",
+			values:   []string{"--hosts", "cli", "--labels", "e=5"},
+			sources:  []uksrc.Source{fileA},
+			expected: Params{Hosts: []string{"cli"}, Labels: map[string]string{"e": "5"}},
+		},
+		{
+			name:     "flags over env over file",
+			values:   []string{"--hosts", "cli1", "--hosts", "cli2", "--labels", "e=5"},
+			env:      map[string]string{"HOSTS": "envh", "LABELS": "d=4"},
+			sources:  []uksrc.Source{fileA},
+			expected: Params{Hosts: []string{"cli1", "cli2"}, Labels: map[string]string{"e": "5"}},
+		},
+		{
+			name:     "mixed layers",
+			values:   []string{"--labels", "e=5"},
+			env:      map[string]string{"HOSTS": "envh"},
+			sources:  []uksrc.Source{fileA},
+			expected: Params{Hosts: []string{"envh"}, Labels: map[string]string{"e": "5"}},
+		},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
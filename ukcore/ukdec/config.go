@@ -2,6 +2,9 @@ package ukdec
 
 import (
 	"log/slog"
+	"os"
+	"reflect"
+	"time"
 
 	"github.com/oligarch316/ukase/internal/ilog"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
@@ -19,6 +22,29 @@ type Config struct {
 
 	// TODO: Document
 	Spec []ukspec.Option
+
+	// TODO: Document
+	EnvLookup func(name string) (value string, exists bool)
+
+	// TODO: Document
+	CheckRequired bool
+
+	// TODO: Document
+	CheckGroups bool
+
+	// TODO: Document
+	Warn func(Warning)
+
+	// TODO: Document
+	TimeLayout string
+
+	// TODO: Document
+	Decoders map[reflect.Type]DecodeFunc
+
+	// Fields assigned by a lower precedence layer (eg. a config file source)
+	// prior to decoding, whose value is replaced rather than merged with by
+	// the first environment or flag value
+	Replace FieldSet
 }
 
 func newConfig(opts []Option) Config {
@@ -34,6 +60,15 @@ func newConfig(opts []Option) Config {
 // =============================================================================
 
 var cfgDefault = Config{
-	Log:  ilog.Discard,
-	Spec: nil,
+	Log:           ilog.Discard,
+	Spec:          nil,
+	EnvLookup:     os.LookupEnv,
+	CheckRequired: true,
+	CheckGroups:   true,
+	Warn:          cfgWarn,
+	TimeLayout:    time.RFC3339,
+	Decoders:      nil,
+	Replace:       nil,
 }
+
+func cfgWarn(Warning) {}
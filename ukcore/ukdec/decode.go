@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"slices"
 
 	"github.com/oligarch316/ukase/internal/ierror"
 	"github.com/oligarch316/ukase/internal/ireflect"
@@ -25,20 +26,128 @@ func Decode(input ukcore.Input, params any, opts ...Option) error {
 	return NewDecoder(input, opts...).Decode(params)
 }
 
+// =============================================================================
+// Defaults
+// =============================================================================
+
+// Default is the source of a value declared via the `ukdefault` tag
+type Default struct {
+	Field string
+	Value string
+}
+
+// DecodeDefaults assigns each field of params declaring a `ukdefault` tag its
+// default value, decoded just as the equivalent user input would be
+func DecodeDefaults(paramsSpec ukspec.Parameters, params any, opts ...Option) error {
+	config := newConfig(opts)
+	field := newFieldDecoder(config)
+
+	paramsVal, err := ireflect.NewParametersValue(params)
+	if err != nil {
+		return InvalidParametersError{Type: reflect.TypeOf(params), err: err}
+	}
+
+	decode := func(name string, index []int, value, sep string) error {
+		if value == "" {
+			return nil
+		}
+
+		def := Default{Field: name, Value: value}
+		fieldVal := paramsVal.EnsureFieldByIndex(index)
+
+		config.Log.Debug("decoding default field", "name", def.Field, "value", def.Value)
+
+		if err := field.decodeFieldSplit(fieldVal, def.Value, sep); err != nil {
+			// Default tags are fixed by the developer, not given by the user
+			err = ierror.FmtD("%s", err)
+			return InvalidFieldError[Default]{Source: def, Destination: fieldVal.Type(), err: err}
+		}
+
+		return nil
+	}
+
+	for _, flagSpec := range paramsSpec.Flags {
+		if err := decode(flagSpec.FieldName, flagSpec.FieldIndex, flagSpec.Default, flagSpec.Split.String()); err != nil {
+			return err
+		}
+	}
+
+	for _, argSpec := range paramsSpec.Arguments {
+		if err := decode(argSpec.FieldName, argSpec.FieldIndex, argSpec.Default, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// =============================================================================
+// Env
+// =============================================================================
+
+type Env struct {
+	Name  string
+	Value string
+}
+
+// =============================================================================
+// Warning
+// =============================================================================
+
+// Warning describes a non-fatal decode concern, ie. a deprecated flag name
+type Warning struct {
+	Flag        ukcore.Flag
+	Replacement string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf(
+		"flag %s is deprecated, use %s instead",
+		labelFlagName(w.Flag.Name), labelFlagName(w.Replacement),
+	)
+}
+
+// =============================================================================
+// FieldSet
+// =============================================================================
+
+// FieldSet records the fields assigned a value from a flag, argument or
+// environment variable, by field index
+type FieldSet [][]int
+
+func (fs FieldSet) Contains(index []int) bool {
+	return slices.ContainsFunc(fs, func(item []int) bool { return slices.Equal(item, index) })
+}
+
+func (fs *FieldSet) insert(index []int) {
+	if !fs.Contains(index) {
+		*fs = append(*fs, slices.Clone(index))
+	}
+}
+
 // =============================================================================
 // Decoder
 // =============================================================================
 
 type Decoder struct {
-	config Config
-	input  ukcore.Input
+	config   Config
+	field    fieldDecoder
+	input    ukcore.Input
+	set      FieldSet
+	warnings []Warning
 }
 
 func NewDecoder(input ukcore.Input, opts ...Option) *Decoder {
 	config := newConfig(opts)
-	return &Decoder{config: config, input: input}
+	return &Decoder{config: config, field: newFieldDecoder(config), input: input}
 }
 
+// SetFields lists the fields assigned by prior calls to Decode
+func (d *Decoder) SetFields() FieldSet { return d.set }
+
+// Warnings lists the warnings emitted by prior calls to Decode
+func (d *Decoder) Warnings() []Warning { return d.warnings }
+
 func (d *Decoder) Decode(params any) error {
 	d.config.Log.Info("decoding parameters", "type", fmt.Sprintf("%T", params))
 
@@ -52,14 +161,87 @@ func (d *Decoder) Decode(params any) error {
 		return err
 	}
 
+	if err := d.decodeEnv(paramsVal, paramsSpec, d.input.Flags); err != nil {
+		return err
+	}
+
 	if err := d.decodeFlags(paramsVal, paramsSpec, d.input.Flags); err != nil {
 		return err
 	}
 
-	return d.decodeArguments(paramsVal, paramsSpec, d.input.Arguments)
+	if err := d.decodeArguments(paramsVal, paramsSpec, d.input.Arguments); err != nil {
+		return err
+	}
+
+	if d.config.CheckGroups {
+		if err := d.checkGroups(paramsSpec); err != nil {
+			return err
+		}
+	}
+
+	if d.config.CheckRequired {
+		return d.checkRequired(paramsVal, paramsSpec)
+	}
+
+	return nil
 }
 
-func (d Decoder) decodeFlags(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, flags []ukcore.Flag) error {
+func (d *Decoder) decodeEnv(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, flags []ukcore.Flag) error {
+	// Environment values only fill fields not already set by a flag
+	// ⇒ Record the env names of all flag fields present in the input
+	present := make(map[ukspec.FlagEnv]struct{})
+	for _, flag := range flags {
+		if flagSpec, ok := paramsSpec.LookupFlag(flag.Name); ok {
+			present[flagSpec.Env] = struct{}{}
+		}
+	}
+
+	for _, flagSpec := range paramsSpec.Flags {
+		if flagSpec.Env == "" {
+			continue
+		}
+
+		if _, ok := present[flagSpec.Env]; ok {
+			continue
+		}
+
+		value, ok := d.config.EnvLookup(flagSpec.Env.String())
+		if !ok {
+			continue
+		}
+
+		env := Env{Name: flagSpec.Env.String(), Value: value}
+		fieldVal := paramsVal.EnsureFieldByIndex(flagSpec.FieldIndex)
+
+		switch {
+		case flagSpec.Repeat == ukspec.FlagRepeatLast || flagSpec.Repeat == ukspec.FlagRepeatReplace:
+			// Environment values are user values just as flags are
+			// ⇒ Discard any existing (default) value
+			fieldVal.SetZero()
+		case d.config.Replace.Contains(flagSpec.FieldIndex):
+			// Environment values take precedence over lower layers
+			// ⇒ Discard the existing (source) value
+			fieldVal.SetZero()
+		}
+
+		d.config.Log.Debug("decoding env field",
+			slog.Group("input", "name", env.Name, "value", env.Value),
+			slog.Group("spec", "type", flagSpec.FieldType, "name", flagSpec.FieldName),
+		)
+
+		if err := d.field.decodeFieldSplit(fieldVal, env.Value, flagSpec.Split.String()); err != nil {
+			return InvalidFieldError[Env]{Source: env, Destination: fieldVal.Type(), err: err}
+		}
+
+		d.set.insert(flagSpec.FieldIndex)
+	}
+
+	return nil
+}
+
+func (d *Decoder) decodeFlags(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, flags []ukcore.Flag) error {
+	var arrays arrayFlags
+
 	for _, flag := range flags {
 		flagSpec, ok := paramsSpec.LookupFlag(flag.Name)
 		if !ok {
@@ -67,22 +249,102 @@ func (d Decoder) decodeFlags(paramsVal ireflect.ParametersValue, paramsSpec uksp
 			return UnknownFieldError[ukcore.Flag]{Source: flag, err: err}
 		}
 
+		if flagSpec.IsDeprecated(flag.Name) {
+			d.warn(Warning{Flag: flag, Replacement: longestFlagName(flagSpec)})
+		}
+
 		fieldVal := paramsVal.EnsureFieldByIndex(flagSpec.FieldIndex)
 
+		if err := d.repeatFlag(fieldVal, flagSpec, flag); err != nil {
+			return err
+		}
+
 		d.config.Log.Debug("decoding flag field",
 			slog.Group("input", "name", flag.Name, "value", flag.Value),
 			slog.Group("spec", "type", flagSpec.FieldType, "name", flagSpec.FieldName),
 		)
 
-		if err := decodeField(fieldVal, flag.Value); err != nil {
+		if flagSpec.Split == "" && d.field.isArrayElems(flagSpec.FieldType) {
+			// Array elements without a split separator
+			// ⇒ Gather one element per flag and decode once all are known
+			arrays.insert(fieldVal, flagSpec, flag)
+			d.set.insert(flagSpec.FieldIndex)
+			continue
+		}
+
+		if err := d.field.decodeFieldSplit(fieldVal, flag.Value, flagSpec.Split.String()); err != nil {
 			return InvalidFieldError[ukcore.Flag]{Source: flag, Destination: fieldVal.Type(), err: err}
 		}
+
+		d.set.insert(flagSpec.FieldIndex)
+	}
+
+	for _, array := range arrays {
+		if err := d.field.decodeFieldArray(array.fieldVal, array.elems); err != nil {
+			return InvalidFieldError[ukcore.Flag]{Source: array.last, Destination: array.fieldVal.Type(), err: err}
+		}
+	}
+
+	return nil
+}
+
+// Array flag values gathered per field, in order of first appearance
+type arrayFlags []arrayFlag
+
+type arrayFlag struct {
+	index    []int
+	fieldVal reflect.Value
+	elems    []string
+	last     ukcore.Flag
+}
+
+func (af *arrayFlags) insert(fieldVal reflect.Value, flagSpec ukspec.Flag, flag ukcore.Flag) {
+	for i := range *af {
+		if array := &(*af)[i]; slices.Equal(array.index, flagSpec.FieldIndex) {
+			array.elems = append(array.elems, flag.Value)
+			array.last = flag
+			return
+		}
+	}
+
+	*af = append(*af, arrayFlag{index: flagSpec.FieldIndex, fieldVal: fieldVal, elems: []string{flag.Value}, last: flag})
+}
+
+// Apply the flag's repeat policy prior to decoding a value into the field.
+// Fields already set during this decode have seen a prior value.
+func (d *Decoder) repeatFlag(fieldVal reflect.Value, flagSpec ukspec.Flag, flag ukcore.Flag) error {
+	first := !d.set.Contains(flagSpec.FieldIndex)
+
+	if first && d.config.Replace.Contains(flagSpec.FieldIndex) {
+		// Flag values take precedence over lower layers
+		// ⇒ Discard the existing (source) value
+		fieldVal.SetZero()
+	}
+
+	switch flagSpec.Repeat {
+	case ukspec.FlagRepeatError:
+		if !first {
+			err := ierror.FmtU("flag %s given more than once", labelFlagName(flag.Name))
+			return RepeatedFieldError{Source: flag, err: err}
+		}
+	case ukspec.FlagRepeatLast:
+		fieldVal.SetZero()
+	case ukspec.FlagRepeatReplace:
+		if first {
+			fieldVal.SetZero()
+		}
 	}
 
 	return nil
 }
 
-func (d Decoder) decodeArguments(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, args []ukcore.Argument) error {
+func (d *Decoder) warn(warning Warning) {
+	d.warnings = append(d.warnings, warning)
+	d.config.Log.Warn("deprecated flag", "name", warning.Flag.Name, "replacement", warning.Replacement)
+	d.config.Warn(warning)
+}
+
+func (d *Decoder) decodeArguments(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, args []ukcore.Argument) error {
 	for _, arg := range args {
 		argSpec, ok := paramsSpec.LookupArgument(arg.Position)
 		if !ok {
@@ -97,10 +359,110 @@ func (d Decoder) decodeArguments(paramsVal ireflect.ParametersValue, paramsSpec
 			slog.Group("spec", "type", argSpec.FieldType, "name", argSpec.FieldName),
 		)
 
-		if err := decodeField(fieldVal, arg.Value); err != nil {
+		if err := d.field.decodeField(fieldVal, arg.Value); err != nil {
 			return InvalidFieldError[ukcore.Argument]{Source: arg, Destination: fieldVal.Type(), err: err}
 		}
+
+		d.set.insert(argSpec.FieldIndex)
+	}
+
+	return nil
+}
+
+// Group constraints consider only those flags present in the input, so values
+// from the environment or assigned prior to decoding never conflict
+func (d Decoder) checkGroups(paramsSpec ukspec.Parameters) error {
+	for _, group := range paramsSpec.Groups {
+		var present, absent []ukspec.Flag
+
+		for _, name := range group.Names {
+			// Group names are validated against the spec when loaded
+			flagSpec, _ := paramsSpec.LookupFlag(name)
+
+			if d.presentInput(paramsSpec, flagSpec) {
+				present = append(present, flagSpec)
+			} else {
+				absent = append(absent, flagSpec)
+			}
+		}
+
+		switch group.Kind {
+		case ukspec.FlagGroupExclusive:
+			if len(present) > 1 {
+				return newInvalidGroupError(group, present, absent)
+			}
+		case ukspec.FlagGroupTogether:
+			if len(present) > 0 && len(absent) > 0 {
+				return newInvalidGroupError(group, present, absent)
+			}
+		}
 	}
 
 	return nil
 }
+
+func (d Decoder) presentInput(paramsSpec ukspec.Parameters, flagSpec ukspec.Flag) bool {
+	for _, flag := range d.input.Flags {
+		if spec, ok := paramsSpec.LookupFlag(flag.Name); ok && slices.Equal(spec.FieldIndex, flagSpec.FieldIndex) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Required fields are satisfied by a matching input flag or argument, by an
+// environment value or by a non-zero field value (eg. assigned by a source)
+func (d Decoder) checkRequired(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters) error {
+	var (
+		missingFlags []ukspec.Flag
+		missingArgs  []ukspec.Argument
+	)
+
+	for _, flagSpec := range paramsSpec.Flags {
+		if flagSpec.Required && !d.presentFlag(paramsVal, paramsSpec, flagSpec) {
+			missingFlags = append(missingFlags, flagSpec)
+		}
+	}
+
+	for _, argSpec := range paramsSpec.Arguments {
+		if argSpec.Required && !d.presentArgument(paramsVal, paramsSpec, argSpec) {
+			missingArgs = append(missingArgs, argSpec)
+		}
+	}
+
+	if len(missingFlags) == 0 && len(missingArgs) == 0 {
+		return nil
+	}
+
+	return newMissingFieldError(missingFlags, missingArgs)
+}
+
+func (d Decoder) presentFlag(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, flagSpec ukspec.Flag) bool {
+	if d.presentInput(paramsSpec, flagSpec) {
+		return true
+	}
+
+	if flagSpec.Env != "" {
+		if _, ok := d.config.EnvLookup(flagSpec.Env.String()); ok {
+			return true
+		}
+	}
+
+	return presentField(paramsVal, flagSpec.FieldIndex)
+}
+
+func (d Decoder) presentArgument(paramsVal ireflect.ParametersValue, paramsSpec ukspec.Parameters, argSpec ukspec.Argument) bool {
+	for _, arg := range d.input.Arguments {
+		if spec, ok := paramsSpec.LookupArgument(arg.Position); ok && slices.Equal(spec.FieldIndex, argSpec.FieldIndex) {
+			return true
+		}
+	}
+
+	return presentField(paramsVal, argSpec.FieldIndex)
+}
+
+func presentField(paramsVal ireflect.ParametersValue, index []int) bool {
+	fieldVal, err := paramsVal.FieldByIndexErr(index)
+	return err == nil && !fieldVal.IsZero()
+}
@@ -1,14 +1,23 @@
 package ukdec_test
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	gocmp "github.com/google/go-cmp/cmp"
 	"github.com/oligarch316/ukase/internal/itest"
 	"github.com/oligarch316/ukase/ukcore"
 	"github.com/oligarch316/ukase/ukcore/ukdec"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
 )
@@ -19,6 +28,15 @@ import (
 
 func ptrTo[T any](val T) *T { return &val }
 
+type decOption func(*ukdec.Config)
+
+func (o decOption) UkaseApplyDec(c *ukdec.Config) { o(c) }
+
+func withEnv(env map[string]string) ukdec.Option {
+	lookup := func(name string) (value string, exists bool) { value, exists = env[name]; return }
+	return decOption(func(c *ukdec.Config) { c.EnvLookup = lookup })
+}
+
 func genInput(in ...string) (input ukcore.Input) {
 	input.Program = "testProgram"
 	input.Target = []string{"testTarget"}
@@ -49,6 +67,7 @@ func TestDecodeError(t *testing.T) {
 
 	type IPE = ukdec.InvalidParametersError
 	type IFEA = ukdec.InvalidFieldError[ukcore.Argument]
+	type IFEE = ukdec.InvalidFieldError[ukdec.Env]
 	type IFEF = ukdec.InvalidFieldError[ukcore.Flag]
 	type UFEA = ukdec.UnknownFieldError[ukcore.Argument]
 	type UFEF = ukdec.UnknownFieldError[ukcore.Flag]
@@ -62,10 +81,11 @@ func TestDecodeError(t *testing.T) {
 		input   ukcore.Input
 		compare func(error) cmp.Comparison
 		params  any
+		opts    []ukdec.Option
 	}
 
 	var runner itest.Runner[subtest] = func(st subtest) (string, cmp.Comparison) {
-		dec := ukdec.NewDecoder(st.input)
+		dec := ukdec.NewDecoder(st.input, st.opts...)
 		err := dec.Decode(st.params)
 		return st.name, st.compare(err)
 	}
@@ -129,11 +149,27 @@ func TestDecodeError(t *testing.T) {
 	t.Run("invalid field", func(t *testing.T) {
 		subtests := []subtest{
 			{
-				name:    "unsupported array",
+				name:    "unsupported array element",
 				input:   genInput("--lorem", "ipsum"),
 				compare: itest.CmpErrorAsD[IFEF],
 				params: new(struct {
-					Lorem [42]int `ukflag:"lorem"`
+					Lorem [1]chan int `ukflag:"lorem"`
+				}),
+			},
+			{
+				name:    "invalid array length",
+				input:   genInput("--lorem", "42,42"),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem [3]int `ukflag:"lorem" uksplit:""`
+				}),
+			},
+			{
+				name:    "invalid repeated array length",
+				input:   genInput("--lorem", "42", "--lorem", "42"),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem [3]int `ukflag:"lorem"`
 				}),
 			},
 			{
@@ -153,11 +189,11 @@ func TestDecodeError(t *testing.T) {
 				}),
 			},
 			{
-				name:    "unsupported map",
-				input:   genInput("--lorem", "ipsum"),
+				name:    "unsupported map element",
+				input:   genInput("--lorem", "42=ipsum"),
 				compare: itest.CmpErrorAsD[IFEF],
 				params: new(struct {
-					Lorem map[int]int `ukflag:"lorem"`
+					Lorem map[int]chan int `ukflag:"lorem"`
 				}),
 			},
 			{
@@ -176,6 +212,54 @@ func TestDecodeError(t *testing.T) {
 					Lorem interface{ bespoke() } `ukflag:"lorem"`
 				}),
 			},
+			{
+				name:    "invalid map entry",
+				input:   genInput("--lorem", "ipsum"),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem map[string]string `ukflag:"lorem"`
+				}),
+			},
+			{
+				name:    "invalid map key",
+				input:   genInput("--lorem", "ipsum=42"),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem map[int]int `ukflag:"lorem"`
+				}),
+			},
+			{
+				name:    "unterminated split quote",
+				input:   genInput("--lorem", `"ipsum,dolor`),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem []string `ukflag:"lorem" uksplit:""`
+				}),
+			},
+			{
+				name:    "incomplete split escape",
+				input:   genInput("--lorem", `ipsum\`),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem []string `ukflag:"lorem" uksplit:""`
+				}),
+			},
+			{
+				name:    "invalid duration",
+				input:   genInput("--lorem", "ipsum"),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem time.Duration `ukflag:"lorem"`
+				}),
+			},
+			{
+				name:    "invalid byte size",
+				input:   genInput("--lorem", "42ipsum"),
+				compare: itest.CmpErrorAsU[IFEF],
+				params: new(struct {
+					Lorem ukdec.ByteSize `ukflag:"lorem"`
+				}),
+			},
 			{
 				name:    "invalid bool",
 				input:   genInput("--lorem", "ipsum"),
@@ -224,6 +308,15 @@ func TestDecodeError(t *testing.T) {
 					Lorem *big.Int `ukflag:"lorem"`
 				}),
 			},
+			{
+				name:    "invalid env",
+				input:   genInput(),
+				compare: itest.CmpErrorAsU[IFEE],
+				params: new(struct {
+					Lorem int `ukflag:"lorem" ukenv:""`
+				}),
+				opts: []ukdec.Option{withEnv(map[string]string{"LOREM": "ipsum"})},
+			},
 		}
 
 		runner.Run(t, subtests...)
@@ -318,6 +411,694 @@ func TestDecodeDirect(t *testing.T) {
 	assert.DeepEqual(t, actual, expected)
 }
 
+func TestDecodeEnv(t *testing.T) {
+	// Decode environment values into flag fields
+	// • Expect› Fields without a flag in the input are loaded from the environment
+	// • Expect› Fields with a flag in the input ignore the environment
+	// • Expect› Inline prefixes apply to derived environment names
+
+	type Inner struct {
+		Host string `ukflag:"host" ukenv:""`
+		Port int    `ukflag:"port" ukenv:""`
+	}
+
+	type Params struct {
+		Lorem string `ukflag:"lorem" ukenv:""`
+		Ipsum string `ukflag:"ipsum" ukenv:"IPSUM_ENV"`
+		Dolor string `ukflag:"dolor"`
+		Inner Inner  `ukinline:"db-"`
+	}
+
+	env := map[string]string{
+		"LOREM":     "lorem-env",
+		"IPSUM_ENV": "ipsum-env",
+		"DOLOR":     "dolor-env",
+		"DB_HOST":   "host-env",
+		"DB_PORT":   "42",
+	}
+
+	input := genInput("--ipsum", "ipsum-flag", "--db-port", "24")
+
+	expected := Params{
+		Lorem: "lorem-env",
+		Ipsum: "ipsum-flag",
+		Dolor: "",
+		Inner: Inner{Host: "host-env", Port: 24},
+	}
+
+	actual, err := ukdec.DecodeFor[Params](input, withEnv(env))
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, actual, expected)
+}
+
+func TestDecodeRequired(t *testing.T) {
+	// Check required fields after decoding
+	// • Expect› Required fields satisfied by input, environment or a prior value succeed
+	// • Expect› All missing required fields are reported at once as a user error
+
+	type Params struct {
+		Lorem string   `ukflag:"lorem" ukreq:""`
+		Ipsum string   `ukflag:"ipsum" ukreq:"" ukenv:""`
+		Dolor string   `ukflag:"d dolor" ukreq:""`
+		Sit   string   `ukarg:"0" ukreq:""`
+		Amet  []string `ukarg:"1:"`
+	}
+
+	t.Run("satisfied", func(t *testing.T) {
+		input := genInput("--lorem", "", "sit")
+		env := map[string]string{"IPSUM": "ipsum-env"}
+		params := Params{Dolor: "dolor-prior"}
+
+		err := ukdec.Decode(input, &params, withEnv(env))
+		assert.NilError(t, err)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		input := genInput("--ipsum", "ipsum-flag")
+		_, err := ukdec.DecodeFor[Params](input, withEnv(nil))
+
+		var actual ukdec.MissingFieldError
+		assert.Assert(t, itest.CmpErrorAsU[ukdec.MissingFieldError](err))
+		assert.Assert(t, errors.As(err, &actual))
+
+		assert.Check(t, cmp.Len(actual.Flags, 2))
+		assert.Check(t, cmp.Len(actual.Arguments, 1))
+		assert.Check(t, cmp.Error(err, "missing required flag '--lorem', flag '--dolor', argument '0'"))
+	})
+
+	t.Run("unchecked", func(t *testing.T) {
+		opt := decOption(func(c *ukdec.Config) { c.CheckRequired = false })
+		_, err := ukdec.DecodeFor[Params](genInput(), withEnv(nil), opt)
+		assert.NilError(t, err)
+	})
+}
+
+func TestDecodeDeprecated(t *testing.T) {
+	// Decode flags via deprecated spellings
+	// • Expect› Deprecated names decode into the same field as current names
+	// • Expect› Each deprecated name used is reported as a warning naming its replacement
+
+	type Params struct {
+		Lorem string `ukflag:"l lorem old-lorem!"`
+		Ipsum int    `ukflag:"ipsum"`
+	}
+
+	var warned []ukdec.Warning
+	opt := decOption(func(c *ukdec.Config) { c.Warn = func(w ukdec.Warning) { warned = append(warned, w) } })
+
+	t.Run("current", func(t *testing.T) {
+		warned = nil
+		decoder := ukdec.NewDecoder(genInput("--lorem", "a", "--ipsum", "1"), opt)
+
+		var actual Params
+		assert.NilError(t, decoder.Decode(&actual))
+		assert.Check(t, cmp.Equal(actual.Lorem, "a"))
+		assert.Check(t, cmp.Len(decoder.Warnings(), 0))
+		assert.Check(t, cmp.Len(warned, 0))
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		warned = nil
+		decoder := ukdec.NewDecoder(genInput("--old-lorem", "a"), opt)
+
+		var actual Params
+		assert.NilError(t, decoder.Decode(&actual))
+		assert.Check(t, cmp.Equal(actual.Lorem, "a"))
+		assert.Assert(t, cmp.Len(decoder.Warnings(), 1))
+		assert.Check(t, cmp.DeepEqual(decoder.Warnings(), warned))
+
+		warning := decoder.Warnings()[0]
+		assert.Check(t, cmp.Equal(warning.Replacement, "lorem"))
+		assert.Check(t, cmp.Equal(warning.String(), "flag '--old-lorem' is deprecated, use '--lorem' instead"))
+	})
+}
+
+func TestDecodeSetFields(t *testing.T) {
+	// Record the fields assigned during decoding
+	// • Expect› Fields assigned by flag, argument or environment are recorded once
+	// • Expect› Fields left untouched, including those with prior values, are not
+
+	type Inner struct {
+		Dolor string `ukflag:"dolor"`
+	}
+
+	type Params struct {
+		Lorem string   `ukflag:"l lorem"`
+		Ipsum string   `ukflag:"ipsum" ukenv:""`
+		Sit   string   `ukflag:"sit"`
+		Inner *Inner   `ukinline:"inner-"`
+		Amet  []string `ukarg:":"`
+	}
+
+	input := genInput("--lorem", "a", "--lorem", "b", "--inner-dolor", "c", "amet")
+	env := map[string]string{"IPSUM": "ipsum-env"}
+	params := Params{Sit: "sit-prior"}
+
+	decoder := ukdec.NewDecoder(input, withEnv(env))
+	assert.NilError(t, decoder.Decode(&params))
+
+	expected := ukdec.FieldSet{{1}, {0}, {3, 0}, {4}}
+	assert.Check(t, cmp.DeepEqual(decoder.SetFields(), expected))
+	assert.Check(t, !decoder.SetFields().Contains([]int{2}))
+}
+
+func TestDecodeReplace(t *testing.T) {
+	// Replace values assigned by a lower layer
+	// • Expect› Fields in Replace are reset by the first flag or environment value
+	// • Expect› Fields not in Replace merge with their prior value
+
+	type Params struct {
+		Hosts  []string          `ukflag:"hosts"`
+		Labels map[string]string `ukflag:"labels" ukenv:""`
+		Tags   []string          `ukflag:"tags"`
+	}
+
+	input := genInput("--hosts", "h2", "--hosts", "h3", "--tags", "t2")
+	env := map[string]string{"LABELS": "b=2"}
+	replace := decOption(func(c *ukdec.Config) { c.Replace = ukdec.FieldSet{{0}, {1}} })
+
+	params := Params{
+		Hosts:  []string{"h1"},
+		Labels: map[string]string{"a": "1"},
+		Tags:   []string{"t1"},
+	}
+
+	expected := Params{
+		Hosts:  []string{"h2", "h3"},
+		Labels: map[string]string{"b": "2"},
+		Tags:   []string{"t1", "t2"},
+	}
+
+	assert.NilError(t, ukdec.Decode(input, &params, withEnv(env), replace))
+	assert.Check(t, cmp.DeepEqual(params, expected))
+}
+
+func TestDecodeRepeat(t *testing.T) {
+	// Decode flags given more than once according to their repeat policy
+	// • Expect› Counters tally occurrences, reset on false and assign integers
+	// • Expect› Scalars keep the last value, slices accumulate by default
+	// • Expect› Replace discards defaults on the first value, last keeps only the final value
+	// • Expect› Error policy fails on the second occurrence as a user error
+
+	type Params struct {
+		Verbose ukdec.Counter `ukflag:"verbose"`
+		Name    string        `ukflag:"name"`
+		Tags    []string      `ukflag:"tag"`
+		Labels  []string      `ukflag:"label" ukrepeat:"replace" ukenv:""`
+		Last    []string      `ukflag:"last" ukrepeat:"last"`
+		Once    string        `ukflag:"once" ukrepeat:"error"`
+		Array   [2]string     `ukflag:"array"`
+		ArrLast [2]string     `ukflag:"array-last" uksplit:"" ukrepeat:"last"`
+		ArrOnce [2]string     `ukflag:"array-once" uksplit:"" ukrepeat:"error"`
+	}
+
+	defaults := func() Params {
+		return Params{Tags: []string{"tag-default"}, Labels: []string{"label-default"}}
+	}
+
+	type subtest struct {
+		name     string
+		input    ukcore.Input
+		env      map[string]string
+		expected Params
+	}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual := defaults()
+		err := ukdec.Decode(st.input, &actual, withEnv(st.env))
+
+		return st.name, itest.CmpSequence(
+			cmp.Nil(err),
+			cmp.DeepEqual(actual, st.expected),
+		)
+	}
+
+	with := func(f func(*Params)) Params { p := defaults(); f(&p); return p }
+
+	subtests := []subtest{
+		{"none", genInput(), nil, defaults()},
+		{
+			name:     "counter",
+			input:    genInput("--verbose", "true", "--verbose", "true", "--verbose", "true"),
+			expected: with(func(p *Params) { p.Verbose = 3 }),
+		},
+		{
+			name:     "counter reset and assign",
+			input:    genInput("--verbose", "true", "--verbose", "false", "--verbose", "5", "--verbose", "true"),
+			expected: with(func(p *Params) { p.Verbose = 6 }),
+		},
+		{
+			name:     "scalar last wins",
+			input:    genInput("--name", "a", "--name", "b"),
+			expected: with(func(p *Params) { p.Name = "b" }),
+		},
+		{
+			name:     "slice accumulate",
+			input:    genInput("--tag", "a", "--tag", "b"),
+			expected: with(func(p *Params) { p.Tags = []string{"tag-default", "a", "b"} }),
+		},
+		{
+			name:     "slice replace",
+			input:    genInput("--label", "a", "--label", "b"),
+			expected: with(func(p *Params) { p.Labels = []string{"a", "b"} }),
+		},
+		{
+			name:     "slice replace via env",
+			input:    genInput(),
+			env:      map[string]string{"LABEL": "env"},
+			expected: with(func(p *Params) { p.Labels = []string{"env"} }),
+		},
+		{
+			name:     "slice last",
+			input:    genInput("--last", "a", "--last", "b"),
+			expected: with(func(p *Params) { p.Last = []string{"b"} }),
+		},
+		{
+			name:     "error once",
+			input:    genInput("--once", "a"),
+			expected: with(func(p *Params) { p.Once = "a" }),
+		},
+		{
+			name:     "array gather",
+			input:    genInput("--array", "a", "--array", "b"),
+			expected: with(func(p *Params) { p.Array = [2]string{"a", "b"} }),
+		},
+		{
+			name:     "array last",
+			input:    genInput("--array-last", "a,b", "--array-last", "c,d"),
+			expected: with(func(p *Params) { p.ArrLast = [2]string{"c", "d"} }),
+		},
+	}
+
+	itest.Run(t, runner, subtests...)
+
+	t.Run("error repeated", func(t *testing.T) {
+		_, err := ukdec.DecodeFor[Params](genInput("--once", "a", "--once", "b"), withEnv(nil))
+		assert.Check(t, itest.CmpErrorAsU[ukdec.RepeatedFieldError](err))
+		assert.Check(t, itest.CmpErrorIs(err, ukdec.ErrRepeatedField))
+		assert.Check(t, cmp.Error(err, "flag '--once' given more than once"))
+	})
+
+	t.Run("array error repeated", func(t *testing.T) {
+		_, err := ukdec.DecodeFor[Params](genInput("--array-once", "a,b", "--array-once", "c,d"), withEnv(nil))
+		assert.Check(t, itest.CmpErrorIs(err, ukdec.ErrRepeatedField))
+		assert.Check(t, cmp.Error(err, "flag '--array-once' given more than once"))
+	})
+
+	t.Run("array unsplit last", func(t *testing.T) {
+		// • Expect› Unsplit arrays are gathered across flags, so repeat policies
+		//   other than the default are rejected
+		type Params struct {
+			Array [2]string `ukflag:"array" ukrepeat:"last"`
+		}
+
+		_, err := ukdec.DecodeFor[Params](genInput("--array", "a", "--array", "b"))
+		assert.Check(t, cmp.ErrorContains(err, "flag repeat policy 'last' requires a split separator for array types"))
+	})
+
+	t.Run("counter invalid", func(t *testing.T) {
+		_, err := ukdec.DecodeFor[Params](genInput("--verbose", "lots"), withEnv(nil))
+		assert.Check(t, itest.CmpErrorAsU[ukdec.InvalidFieldError[ukcore.Flag]](err))
+	})
+}
+
+func TestDecodeErrorMessage(t *testing.T) {
+	// Render invalid field errors for end users
+	// • Expect› Messages name the source as given and the offending value
+	// • Expect› Messages describe the expected type, with ranges for sized integers
+	// • Expect› Structured details are available via InvalidValueError
+
+	type Params struct {
+		Port     uint16        `ukflag:"p port" ukenv:"PORT"`
+		Level    int8          `ukflag:"level"`
+		Count    int           `ukflag:"count"`
+		Verbose  bool          `ukflag:"verbose"`
+		Ratio    float64       `ukflag:"ratio"`
+		Timeout  time.Duration `ukflag:"timeout"`
+		Hosts    []netip.Addr  `ukflag:"hosts" uksplit:""`
+		Position int32         `ukarg:"0"`
+	}
+
+	type subtest struct {
+		name     string
+		input    ukcore.Input
+		env      map[string]string
+		expected string
+	}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		_, err := ukdec.DecodeFor[Params](st.input, withEnv(st.env))
+
+		return st.name, itest.CmpSequence(
+			itest.CmpErrorAsU[ukdec.InvalidValueError](err),
+			itest.CmpErrorIs(err, ukdec.ErrInvalidValue),
+			cmp.Error(err, st.expected),
+		)
+	}
+
+	shortInput := genInput()
+	shortInput.Flags = []ukcore.Flag{{Name: "p", Value: "http"}}
+
+	subtests := []subtest{
+		{
+			name:     "sized unsigned",
+			input:    genInput("--port", "70000"),
+			expected: "flag '--port': invalid value '70000', expected an integer between 0 and 65535",
+		},
+		{
+			name:     "sized unsigned short name",
+			input:    shortInput,
+			expected: "flag '-p': invalid value 'http', expected an integer between 0 and 65535",
+		},
+		{
+			name:     "sized signed",
+			input:    genInput("--level", "-200"),
+			expected: "flag '--level': invalid value '-200', expected an integer between -128 and 127",
+		},
+		{
+			name:     "unsized signed",
+			input:    genInput("--count", "lorem"),
+			expected: "flag '--count': invalid value 'lorem', expected an integer",
+		},
+		{
+			name:     "bool",
+			input:    genInput("--verbose", "ipsum"),
+			expected: "flag '--verbose': invalid value 'ipsum', expected a boolean (true or false)",
+		},
+		{
+			name:     "float",
+			input:    genInput("--ratio", "half"),
+			expected: "flag '--ratio': invalid value 'half', expected a number",
+		},
+		{
+			name:     "duration",
+			input:    genInput("--timeout", "10"),
+			expected: "flag '--timeout': invalid value '10', expected a duration (eg. 1m30s)",
+		},
+		{
+			name:     "split element",
+			input:    genInput("--hosts", "192.0.2.1,dolor"),
+			expected: "flag '--hosts': invalid value 'dolor', expected an IP address",
+		},
+		{
+			name:     "env",
+			input:    genInput(),
+			env:      map[string]string{"PORT": "-1"},
+			expected: "env 'PORT': invalid value '-1', expected an integer between 0 and 65535",
+		},
+		{
+			name:     "argument",
+			input:    genInput("sit"),
+			expected: "argument '0': invalid value 'sit', expected an integer between -2147483648 and 2147483647",
+		},
+	}
+
+	itest.Run(t, runner, subtests...)
+
+	t.Run("details", func(t *testing.T) {
+		_, err := ukdec.DecodeFor[Params](genInput("--level", "lorem"), withEnv(nil))
+
+		var target ukdec.InvalidValueError
+		assert.Assert(t, errors.As(err, &target))
+		assert.Check(t, cmp.Equal(target.Value, "lorem"))
+		assert.Check(t, cmp.Equal(target.Type, reflect.TypeFor[int8]()))
+		assert.Check(t, cmp.Equal(target.Min, "-128"))
+		assert.Check(t, cmp.Equal(target.Max, "127"))
+	})
+}
+
+func TestDecodeDefaults(t *testing.T) {
+	// Decode `ukdefault` tag values
+	// • Expect› Defaults are decoded as user input, including split values
+	// • Expect› Fields without a default tag are left untouched
+	// • Expect› Invalid defaults fail as a developer error naming the field
+
+	type Inner struct {
+		Host string `ukflag:"host" ukdefault:"localhost"`
+	}
+
+	type Params struct {
+		Inner   *Inner        `ukinline:"db-"`
+		Port    uint16        `ukflag:"port" ukdefault:"8080"`
+		Timeout time.Duration `ukflag:"timeout" ukdefault:"30s"`
+		Tags    []string      `ukflag:"tag" uksplit:"" ukdefault:"a,b"`
+		Name    string        `ukflag:"name"`
+		Target  string        `ukarg:"0" ukdefault:"."`
+	}
+
+	spec, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	actual := Params{Name: "existing"}
+	err = ukdec.DecodeDefaults(spec, &actual)
+	assert.NilError(t, err)
+
+	expected := Params{
+		Inner:   &Inner{Host: "localhost"},
+		Port:    8080,
+		Timeout: 30 * time.Second,
+		Tags:    []string{"a", "b"},
+		Name:    "existing",
+		Target:  ".",
+	}
+
+	assert.Check(t, cmp.DeepEqual(actual, expected))
+
+	t.Run("invalid", func(t *testing.T) {
+		type Params struct {
+			Port uint16 `ukflag:"port" ukdefault:"http"`
+		}
+
+		spec, err := ukspec.ParametersFor[Params]()
+		assert.NilError(t, err)
+
+		err = ukdec.DecodeDefaults(spec, new(Params))
+		assert.Check(t, itest.CmpErrorAsD[ukdec.InvalidFieldError[ukdec.Default]](err))
+		assert.Check(t, cmp.Error(err, "default for field 'Port': invalid value 'http', expected an integer between 0 and 65535"))
+	})
+}
+
+func TestDecodeSplit(t *testing.T) {
+	// Decode delimited slice and map values
+	// • Expect› Split values are divided on unquoted, unescaped separators
+	// • Expect› Map values are "key=value" pairs split on the first '='
+	// • Expect› Split elements accumulate across repeated flags and env values
+
+	type Params struct {
+		Hosts  []string          `ukflag:"hosts" uksplit:""`
+		Ports  []int             `ukflag:"ports" uksplit:":"`
+		Labels map[string]string `ukflag:"label" uksplit:"" ukenv:""`
+		Limits map[string]int    `ukflag:"limit"`
+		Groups map[string][]int  `ukflag:"group"`
+		Plain  []string          `ukflag:"plain"`
+	}
+
+	type subtest struct {
+		name     string
+		input    ukcore.Input
+		env      map[string]string
+		expected Params
+	}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual, err := ukdec.DecodeFor[Params](st.input, withEnv(st.env))
+
+		return st.name, itest.CmpSequence(
+			cmp.Nil(err),
+			cmp.DeepEqual(actual, st.expected),
+		)
+	}
+
+	subtests := []subtest{
+		{
+			name:     "slice",
+			input:    genInput("--hosts", "x,y,z"),
+			expected: Params{Hosts: []string{"x", "y", "z"}},
+		},
+		{
+			name:     "slice repeated",
+			input:    genInput("--hosts", "x,y", "--hosts", "z"),
+			expected: Params{Hosts: []string{"x", "y", "z"}},
+		},
+		{
+			name:     "slice custom separator",
+			input:    genInput("--ports", "80:443"),
+			expected: Params{Ports: []int{80, 443}},
+		},
+		{
+			name:     "slice escaped",
+			input:    genInput("--hosts", `x\,y,z`),
+			expected: Params{Hosts: []string{"x,y", "z"}},
+		},
+		{
+			name:     "slice quoted",
+			input:    genInput("--hosts", `"x,y",z`),
+			expected: Params{Hosts: []string{"x,y", "z"}},
+		},
+		{
+			name:     "slice empty elements",
+			input:    genInput("--hosts", "x,,y,"),
+			expected: Params{Hosts: []string{"x", "", "y", ""}},
+		},
+		{
+			name:     "slice empty value",
+			input:    genInput("--hosts", ""),
+			expected: Params{},
+		},
+		{
+			name:     "slice unsplit",
+			input:    genInput("--plain", "x,y"),
+			expected: Params{Plain: []string{"x,y"}},
+		},
+		{
+			name:     "map",
+			input:    genInput("--label", "a=1,b=2"),
+			expected: Params{Labels: map[string]string{"a": "1", "b": "2"}},
+		},
+		{
+			name:     "map value containing separators",
+			input:    genInput("--label", `a="x,y=z",b=\"2\"`),
+			expected: Params{Labels: map[string]string{"a": "x,y=z", "b": `"2"`}},
+		},
+		{
+			name:     "map repeated",
+			input:    genInput("--label", "a=1,b=2", "--label", "a=3"),
+			expected: Params{Labels: map[string]string{"a": "3", "b": "2"}},
+		},
+		{
+			name:     "map via env",
+			input:    genInput(),
+			env:      map[string]string{"LABEL": "a=1,b=2"},
+			expected: Params{Labels: map[string]string{"a": "1", "b": "2"}},
+		},
+		{
+			name:     "map unsplit",
+			input:    genInput("--limit", "a=1", "--limit", "b=2"),
+			expected: Params{Limits: map[string]int{"a": 1, "b": 2}},
+		},
+		{
+			name:     "map slice values",
+			input:    genInput("--group", "a=1", "--group", "a=2"),
+			expected: Params{Groups: map[string][]int{"a": {1, 2}}},
+		},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
+type groupParams struct {
+	Tag     string `ukflag:"tag"`
+	Digest  string `ukflag:"d digest" ukenv:""`
+	TLSCert string `ukflag:"tls-cert"`
+	TLSKey  string `ukflag:"tls-key"`
+}
+
+func (groupParams) UkaseGroups() []ukspec.FlagGroup {
+	return []ukspec.FlagGroup{
+		ukspec.Exclusive("tag", "digest"),
+		ukspec.Together("tls-cert", "tls-key"),
+	}
+}
+
+func TestDecodeGroups(t *testing.T) {
+	// Check flag group constraints after decoding
+	// • Expect› Only flags present in the input count toward a group
+	// • Expect› Violations fail as a user error naming the offending flags
+
+	type subtest struct {
+		name     string
+		input    ukcore.Input
+		expected string
+	}
+
+	env := map[string]string{"DIGEST": "sha256:env"}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		_, err := ukdec.DecodeFor[groupParams](st.input, withEnv(env))
+
+		if st.expected == "" {
+			return st.name, cmp.Nil(err)
+		}
+
+		return st.name, itest.CmpSequence(
+			itest.CmpErrorAsU[ukdec.InvalidGroupError](err),
+			itest.CmpErrorIs(err, ukdec.ErrInvalidGroup),
+			cmp.Error(err, st.expected),
+		)
+	}
+
+	subtests := []subtest{
+		{"none", genInput(), ""},
+		{"exclusive single with env", genInput("--tag", "v1"), ""},
+		{"exclusive both", genInput("--tag", "v1", "--digest", "sha256:x"), "flags '--tag', '--digest' are mutually exclusive"},
+		{"together all", genInput("--tls-cert", "c", "--tls-key", "k"), ""},
+		{"together partial", genInput("--tls-cert", "c"), "flag '--tls-cert' requires '--tls-key'"},
+	}
+
+	itest.Run(t, runner, subtests...)
+
+	t.Run("unchecked", func(t *testing.T) {
+		opt := decOption(func(c *ukdec.Config) { c.CheckGroups = false })
+		_, err := ukdec.DecodeFor[groupParams](genInput("--tls-cert", "c"), withEnv(nil), opt)
+		assert.NilError(t, err)
+	})
+}
+
+type choiceFormat string
+
+func (choiceFormat) UkaseChoices() []string { return []string{"json", "text", "yaml"} }
+
+func TestDecodeChoices(t *testing.T) {
+	// Decode into types listing their valid values via `UkaseChoices`
+	// • Expect› Listed values decode as normal, including slice elements
+	// • Expect› Unlisted values fail as a user error with suggestions
+
+	type Params struct {
+		Format  choiceFormat   `ukflag:"format"`
+		Formats []choiceFormat `ukflag:"formats"`
+		Pointer *choiceFormat  `ukflag:"pointer"`
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		input := genInput("--format", "json", "--formats", "text", "--formats", "yaml", "--pointer", "text")
+		actual, err := ukdec.DecodeFor[Params](input)
+
+		pointer := choiceFormat("text")
+		expected := Params{Format: "json", Formats: []choiceFormat{"text", "yaml"}, Pointer: &pointer}
+
+		assert.NilError(t, err)
+		assert.DeepEqual(t, actual, expected)
+	})
+
+	type subtest struct {
+		name        string
+		input       ukcore.Input
+		suggestions []string
+	}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		_, err := ukdec.DecodeFor[Params](st.input)
+
+		var actual ukdec.InvalidChoiceError
+		errors.As(err, &actual)
+
+		return st.name, itest.CmpSequence(
+			itest.CmpErrorAsU[ukdec.InvalidChoiceError](err),
+			cmp.DeepEqual(actual.Suggestions, st.suggestions),
+		)
+	}
+
+	subtests := []subtest{
+		{"suggestion", genInput("--format", "jsn"), []string{"json"}},
+		{"no suggestion", genInput("--format", "lorem"), nil},
+		{"slice element", genInput("--formats", "text", "--formats", "ymal"), []string{"yaml"}},
+		{"pointer", genInput("--pointer", "txt"), []string{"text"}},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
 func TestDecodeIndirect(t *testing.T) {
 	// Decode into indirect types
 	// • Scope› Indirect types = { interface, pointer }
@@ -365,24 +1146,175 @@ func TestDecodeIndirect(t *testing.T) {
 
 func TestDecodeContainer(t *testing.T) {
 	// Decode into container types
-	// • Scope› Container types = { slice }
+	// • Scope› Container types = { slice, array }
 	// • Scope› Container<Direct> types, 1 level of recursion
 	// • Scope› Input fields are uninformed (zero-value)
 	//
 	// • Expect› Slice fields have correct element types created and loaded
+	// • Expect› Array fields are loaded from a single, split or repeated value
 
 	type Params struct {
-		Lorem []int `ukflag:"lorem"`
+		Lorem []int     `ukflag:"lorem"`
+		Ipsum [1]string `ukflag:"ipsum"`
+		Dolor *[2]int   `ukflag:"dolor" uksplit:""`
+		Sit   [2]int    `ukflag:"sit"`
 	}
 
-	input := genInput("--lorem", "-42")
-	expected := Params{Lorem: []int{-42}}
+	input := genInput("--lorem", "-42", "--ipsum", "sit", "--dolor", "42,-42", "--sit", "1", "--sit", "2")
+	expected := Params{Lorem: []int{-42}, Ipsum: [1]string{"sit"}, Dolor: &[2]int{42, -42}, Sit: [2]int{1, 2}}
 	actual, err := ukdec.DecodeFor[Params](input)
 
 	assert.NilError(t, err)
 	assert.DeepEqual(t, actual, expected)
 }
 
+func TestDecodeKnown(t *testing.T) {
+	// Decode into well-known types
+	// • Scope› Known types = { time, url, netip, regexp, byte size }
+	//
+	// • Expect› Known types are decoded by type rather than by kind
+	// • Expect› Time values are decoded according to the configured layout
+
+	type Params struct {
+		Duration time.Duration    `ukflag:"duration"`
+		Time     time.Time        `ukflag:"time"`
+		URL      *url.URL         `ukflag:"url"`
+		Addr     netip.Addr       `ukflag:"addr"`
+		AddrPort netip.AddrPort   `ukflag:"addr-port"`
+		Prefix   netip.Prefix     `ukflag:"prefix"`
+		Regexp   *regexp.Regexp   `ukflag:"regexp"`
+		Size     ukdec.ByteSize   `ukflag:"size"`
+		Sizes    []ukdec.ByteSize `ukflag:"sizes" uksplit:""`
+	}
+
+	input := genInput(
+		"--duration", "1m30s",
+		"--time", "2024-01-02T03:04:05Z",
+		"--url", "https://example.com/path?q=1",
+		"--addr", "192.0.2.1",
+		"--addr-port", "[2001:db8::1]:443",
+		"--prefix", "10.0.0.0/8",
+		"--regexp", "^lorem-[0-9]+$",
+		"--size", "10MiB",
+		"--sizes", "512,1.5kB,2 GiB,1kib",
+	)
+
+	actual, err := ukdec.DecodeFor[Params](input)
+	assert.NilError(t, err)
+
+	assert.Check(t, cmp.Equal(actual.Duration, 90*time.Second))
+	assert.Check(t, actual.Time.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+	assert.Check(t, cmp.Equal(actual.URL.String(), "https://example.com/path?q=1"))
+	assert.Check(t, cmp.Equal(actual.Addr, netip.MustParseAddr("192.0.2.1")))
+	assert.Check(t, cmp.Equal(actual.AddrPort, netip.MustParseAddrPort("[2001:db8::1]:443")))
+	assert.Check(t, cmp.Equal(actual.Prefix, netip.MustParsePrefix("10.0.0.0/8")))
+	assert.Check(t, actual.Regexp.MatchString("lorem-42"))
+	assert.Check(t, cmp.Equal(actual.Size, ukdec.ByteSize(10<<20)))
+	assert.Check(t, cmp.DeepEqual(actual.Sizes, []ukdec.ByteSize{512, 1500, 2 << 30, 1 << 10}))
+
+	t.Run("time layout", func(t *testing.T) {
+		type Params struct {
+			Date time.Time `ukflag:"date"`
+		}
+
+		layout := decOption(func(c *ukdec.Config) { c.TimeLayout = time.DateOnly })
+		actual, err := ukdec.DecodeFor[Params](genInput("--date", "2024-01-02"), layout)
+
+		assert.NilError(t, err)
+		assert.Check(t, actual.Date.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("regexp pointer", func(t *testing.T) {
+		type Params struct {
+			Regexp   *regexp.Regexp  `ukflag:"regexp"`
+			Indirect **regexp.Regexp `ukflag:"indirect"`
+		}
+
+		prior := regexp.MustCompile("^prior$")
+		params := Params{Regexp: prior}
+
+		input := genInput("--regexp", "^lorem$", "--indirect", "^ipsum$")
+		assert.NilError(t, ukdec.Decode(input, &params))
+
+		// • Expect› The compiled pointer is assigned, leaving any prior value intact
+		assert.Check(t, params.Regexp != prior)
+		assert.Check(t, cmp.Equal(prior.String(), "^prior$"))
+		assert.Check(t, cmp.Equal(params.Regexp.String(), "^lorem$"))
+		assert.Check(t, cmp.Equal((*params.Indirect).String(), "^ipsum$"))
+	})
+
+	t.Run("regexp invalid", func(t *testing.T) {
+		type Params struct {
+			Regexp *regexp.Regexp `ukflag:"regexp"`
+		}
+
+		_, err := ukdec.DecodeFor[Params](genInput("--regexp", "(lorem"))
+		assert.Check(t, cmp.ErrorContains(err, "missing closing )"))
+	})
+
+	t.Run("byte size string", func(t *testing.T) {
+		for size, expected := range map[ukdec.ByteSize]string{0: "0B", 1500: "1500B", 10 << 20: "10MiB", 3 << 40: "3TiB"} {
+			assert.Check(t, cmp.Equal(size.String(), expected))
+		}
+	})
+}
+
+type registeredPoint struct{ X, Y int }
+
+func parseRegisteredPoint(s string) (registeredPoint, error) {
+	var p registeredPoint
+	_, err := fmt.Sscanf(s, "%d:%d", &p.X, &p.Y)
+	return p, err
+}
+
+func TestDecodeRegistered(t *testing.T) {
+	// Decode using registered decoders
+	// • Expect› Registered types are decoded directly and via indirection
+	// • Expect› Registered decoders take precedence over built-in and custom decoding
+	// • Expect› Registered decoder errors are user errors
+
+	type Params struct {
+		Point    registeredPoint    `ukflag:"point"`
+		Points   []*registeredPoint `ukflag:"points" uksplit:""`
+		Duration time.Duration      `ukflag:"duration"`
+		Big      *big.Int           `ukflag:"big"`
+	}
+
+	parseSeconds := func(s string) (time.Duration, error) {
+		n, err := strconv.Atoi(s)
+		return time.Duration(n) * time.Second, err
+	}
+
+	parseBig := func(s string) (big.Int, error) {
+		var n big.Int
+		_, ok := n.SetString(s, 16)
+		if !ok {
+			return n, errors.New("invalid hex")
+		}
+		return n, nil
+	}
+
+	opts := []ukdec.Option{
+		ukdec.RegisterDecoder(parseRegisteredPoint),
+		ukdec.RegisterDecoder(parseSeconds),
+		ukdec.RegisterDecoder(parseBig),
+	}
+
+	input := genInput("--point", "1:2", "--points", "3:4,5:6", "--duration", "90", "--big", "ff")
+	actual, err := ukdec.DecodeFor[Params](input, opts...)
+	assert.NilError(t, err)
+
+	assert.Check(t, cmp.DeepEqual(actual.Point, registeredPoint{1, 2}))
+	assert.Check(t, cmp.DeepEqual(actual.Points, []*registeredPoint{{3, 4}, {5, 6}}))
+	assert.Check(t, cmp.Equal(actual.Duration, 90*time.Second))
+	assert.Check(t, cmp.Equal(actual.Big.Int64(), int64(255)))
+
+	t.Run("error", func(t *testing.T) {
+		_, err := ukdec.DecodeFor[Params](genInput("--point", "lorem"), opts...)
+		assert.Check(t, itest.CmpErrorAsU[ukdec.InvalidFieldError[ukcore.Flag]](err))
+	})
+}
+
 func TestDecodeCustom(t *testing.T) {
 	// Decode into custom types
 	// • Scope› Custom types = { encoding.TextUnmarshaler }
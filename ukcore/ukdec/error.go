@@ -4,13 +4,25 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/internal/ispec"
+	"github.com/oligarch316/ukase/internal/isuggest"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
 )
 
 var (
-	ErrInvalidField = errors.New("invalid field error")
-	ErrUnknownField = errors.New("unknown field error")
+	ErrInvalidField  = errors.New("invalid field error")
+	ErrUnknownField  = errors.New("unknown field error")
+	ErrMissingField  = errors.New("missing field error")
+	ErrInvalidChoice = errors.New("invalid choice error")
+	ErrInvalidGroup  = errors.New("invalid group error")
+	ErrRepeatedField = errors.New("repeated field error")
+	ErrInvalidValue  = errors.New("invalid value error")
 )
 
 type InvalidParametersError struct {
@@ -24,24 +36,173 @@ type InvalidFieldError[S any] struct {
 	err         error
 }
 
+// InvalidValueError describes a value unparsable as its destination type, in
+// terms meaningful to end users, eg. "expected an integer between 0 and 255"
+type InvalidValueError struct {
+	Value    string
+	Type     reflect.Type
+	Expected string
+
+	// Bounds of sized integer types, empty otherwise
+	Min, Max string
+
+	err error
+}
+
+func newInvalidValueError(t reflect.Type, value, expected string, cause error) error {
+	return InvalidValueError{Type: t, Value: value, Expected: expected, err: ierror.U(cause)}
+}
+
 type UnknownFieldError[S any] struct {
 	Source S
 	err    error
 }
 
+type RepeatedFieldError struct {
+	Source ukcore.Flag
+	err    error
+}
+
+type MissingFieldError struct {
+	Flags     []ukspec.Flag
+	Arguments []ukspec.Argument
+	err       error
+}
+
+func newMissingFieldError(flags []ukspec.Flag, args []ukspec.Argument) error {
+	var items []string
+
+	for _, flag := range flags {
+		items = append(items, "flag "+labelFlag(flag))
+	}
+
+	for _, arg := range args {
+		items = append(items, "argument "+labelArgument(arg))
+	}
+
+	err := ierror.FmtU("missing required %s", strings.Join(items, ", "))
+	return MissingFieldError{Flags: flags, Arguments: args, err: err}
+}
+
+type InvalidChoiceError struct {
+	Value       string
+	Choices     []string
+	Suggestions []string
+	err         error
+}
+
+func newInvalidChoiceError(value string, choices []string) error {
+	suggestions := isuggest.Closest(value, choices)
+	message := fmt.Sprintf("invalid choice '%s'", value)
+
+	if len(suggestions) != 0 {
+		message += fmt.Sprintf(", did you mean '%s'?", suggestions[0])
+	}
+
+	err := ierror.FmtU("%s (choose from %s)", message, strings.Join(choices, ", "))
+	return InvalidChoiceError{Value: value, Choices: choices, Suggestions: suggestions, err: err}
+}
+
+type InvalidGroupError struct {
+	Group   ukspec.FlagGroup
+	Present []ukspec.Flag
+	err     error
+}
+
+func newInvalidGroupError(group ukspec.FlagGroup, present, absent []ukspec.Flag) error {
+	var err error
+
+	switch group.Kind {
+	case ukspec.FlagGroupExclusive:
+		err = ierror.FmtU("flags %s are mutually exclusive", labelFlags(present))
+	default:
+		err = ierror.FmtU("flag %s requires %s", labelFlags(present), labelFlags(absent))
+	}
+
+	return InvalidGroupError{Group: group, Present: present, err: err}
+}
+
+func labelFlags(flags []ukspec.Flag) string {
+	labels := make([]string, len(flags))
+	for i, flag := range flags {
+		labels[i] = labelFlag(flag)
+	}
+	return strings.Join(labels, ", ")
+}
+
+func labelFlag(flag ukspec.Flag) string {
+	return labelFlagName(longestFlagName(flag))
+}
+
+func labelFlagName(name string) string {
+	return "'" + ispec.LabelFlag(name) + "'"
+}
+
+// Prefer the longest current (non-deprecated) name
+func longestFlagName(flag ukspec.Flag) string {
+	return slices.MaxFunc(flag.Current(), func(a, b string) int {
+		return utf8.RuneCountInString(a) - utf8.RuneCountInString(b)
+	})
+}
+
+func labelArgument(arg ukspec.Argument) string {
+	if low, high := arg.Position.Low, arg.Position.High; low != nil && high != nil && *high == *low+1 {
+		return fmt.Sprintf("'%d'", *low)
+	}
+	return fmt.Sprintf("'%s'", arg.Position)
+}
+
 var errIsTagged = ierror.IsTaggedFunc(ierror.ErrDec)
 
 func (e InvalidParametersError) Is(t error) bool { return errIsTagged(t) }
 func (e InvalidFieldError[S]) Is(t error) bool   { return errIsTagged(t, ErrInvalidField) }
 func (e UnknownFieldError[S]) Is(t error) bool   { return errIsTagged(t, ErrUnknownField) }
+func (e MissingFieldError) Is(t error) bool      { return errIsTagged(t, ErrMissingField) }
+func (e RepeatedFieldError) Is(t error) bool     { return errIsTagged(t, ErrRepeatedField) }
+func (e InvalidChoiceError) Is(t error) bool     { return errIsTagged(t, ErrInvalidChoice) }
+func (e InvalidGroupError) Is(t error) bool      { return errIsTagged(t, ErrInvalidGroup) }
+func (e InvalidValueError) Is(t error) bool      { return errIsTagged(t, ErrInvalidValue) }
 
 func (e InvalidParametersError) Unwrap() error { return e.err }
 func (e InvalidFieldError[S]) Unwrap() error   { return e.err }
 func (e UnknownFieldError[S]) Unwrap() error   { return e.err }
+func (e MissingFieldError) Unwrap() error      { return e.err }
+func (e RepeatedFieldError) Unwrap() error     { return e.err }
+func (e InvalidChoiceError) Unwrap() error     { return e.err }
+func (e InvalidGroupError) Unwrap() error      { return e.err }
+func (e InvalidValueError) Unwrap() error      { return e.err }
 
 func (e InvalidParametersError) Error() string {
 	return fmt.Sprintf("invalid parameters '%s': %s", e.Type, e.err)
 }
 
-func (e InvalidFieldError[S]) Error() string { return e.err.Error() }
+// Field errors name the source as given by the user, eg. the flag "-p" rather
+// than its longest name "--port"
+func (e InvalidFieldError[S]) Error() string {
+	switch source := any(e.Source).(type) {
+	case ukcore.Flag:
+		return fmt.Sprintf("flag %s: %s", labelFlagName(source.Name), e.err)
+	case ukcore.Argument:
+		return fmt.Sprintf("argument '%d': %s", source.Position, e.err)
+	case Env:
+		return fmt.Sprintf("env '%s': %s", source.Name, e.err)
+	case Default:
+		return fmt.Sprintf("default for field '%s': %s", source.Field, e.err)
+	default:
+		return e.err.Error()
+	}
+}
+
+func (e InvalidValueError) Error() string {
+	if e.Expected == "" {
+		// No plain description (eg. a registered decoder) ⇒ defer to the cause
+		return fmt.Sprintf("invalid value '%s': %s", e.Value, e.err)
+	}
+	return fmt.Sprintf("invalid value '%s', expected %s", e.Value, e.Expected)
+}
+
 func (e UnknownFieldError[S]) Error() string { return e.err.Error() }
+func (e MissingFieldError) Error() string    { return e.err.Error() }
+func (e RepeatedFieldError) Error() string   { return e.err.Error() }
+func (e InvalidChoiceError) Error() string   { return e.err.Error() }
+func (e InvalidGroupError) Error() string    { return e.err.Error() }
@@ -2,8 +2,12 @@ package ukdec
 
 import (
 	"encoding"
+	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/oligarch316/ukase/internal/ierror"
 )
@@ -14,8 +18,24 @@ import (
 // › Indirect and container decode logic recurses back here
 // =============================================================================
 
-func decodeField(dst reflect.Value, src string) error {
-	if complete, err := decodeFieldIndirect(dst, src); complete {
+type fieldDecoder struct {
+	known map[reflect.Type]DecodeFunc
+}
+
+func newFieldDecoder(config Config) fieldDecoder {
+	return fieldDecoder{known: knownDecoders(config)}
+}
+
+func (fd fieldDecoder) decodeField(dst reflect.Value, src string) error {
+	if complete, err := fd.decodeFieldIndirect(dst, src); complete {
+		return err
+	}
+
+	if err := checkFieldChoices(dst, src); err != nil {
+		return err
+	}
+
+	if complete, err := fd.decodeFieldKnown(dst, src); complete {
 		return err
 	}
 
@@ -23,7 +43,7 @@ func decodeField(dst reflect.Value, src string) error {
 		return err
 	}
 
-	if complete, err := decodeFieldDirect(dst, src); complete {
+	if complete, err := fd.decodeFieldDirect(dst, src); complete {
 		return err
 	}
 
@@ -33,23 +53,154 @@ func decodeField(dst reflect.Value, src string) error {
 	return ierror.FmtD("unsupported destination kind '%s'", dst.Kind())
 }
 
+// =============================================================================
+// Split Field
+// › Splits a single value into elements prior to dispatch
+// › Array destinations receive all elements at once
+// › Separators are escaped by a preceding '\' or enclosed in '"' quotes
+// =============================================================================
+
+func (fd fieldDecoder) decodeFieldSplit(dst reflect.Value, src string, sep string) error {
+	if sep == "" {
+		return fd.decodeField(dst, src)
+	}
+
+	elems, err := splitValue(src, sep)
+	if err != nil {
+		return err
+	}
+
+	if indirectType(dst.Type()).Kind() == reflect.Array {
+		return fd.decodeFieldArray(dst, elems)
+	}
+
+	for _, elem := range elems {
+		if err := fd.decodeField(dst, elem); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode all elements of an array destination at once, replacing any
+// existing value
+func (fd fieldDecoder) decodeFieldArray(dst reflect.Value, elems []string) error {
+	arrayVal := reflect.New(indirectType(dst.Type())).Elem()
+	if err := fd.decodeArray(arrayVal, elems); err != nil {
+		return err
+	}
+
+	indirectValue(dst).Set(arrayVal)
+	return nil
+}
+
+// Report whether values for a destination of type t are array elements, ie.
+// an array type not otherwise handled by a known or custom decoder. Such
+// values are gathered across repeated flags, as slice values accumulate.
+func (fd fieldDecoder) isArrayElems(t reflect.Type) bool {
+	t = indirectType(t)
+
+	if _, ok := fd.known[t]; ok {
+		return false
+	}
+
+	if t.Implements(typeTextUnmarshaler) || reflect.PointerTo(t).Implements(typeTextUnmarshaler) {
+		return false
+	}
+
+	return t.Kind() == reflect.Array
+}
+
+// Split src on each unquoted and unescaped occurrence of sep, removing quotes
+// and escapes from the resulting elements, eg. with sep ","
+// › `a,b`       ⇒ ["a", "b"]
+// › `a\,b,c`    ⇒ ["a,b", "c"]
+// › `"a,b",c`   ⇒ ["a,b", "c"]
+// › (empty)     ⇒ []
+func splitValue(src, sep string) ([]string, error) {
+	if src == "" {
+		return nil, nil
+	}
+
+	var (
+		elems  []string
+		elem   strings.Builder
+		quoted bool
+	)
+
+	for i := 0; i < len(src); {
+		switch {
+		case src[i] == '\\':
+			r, size := utf8.DecodeRuneInString(src[i+1:])
+			if size == 0 {
+				return nil, ierror.FmtU("value '%s' ends with an incomplete escape", src)
+			}
+
+			elem.WriteRune(r)
+			i += 1 + size
+		case src[i] == '"':
+			quoted = !quoted
+			i++
+		case !quoted && strings.HasPrefix(src[i:], sep):
+			elems = append(elems, elem.String())
+			elem.Reset()
+			i += len(sep)
+		default:
+			r, size := utf8.DecodeRuneInString(src[i:])
+			elem.WriteRune(r)
+			i += size
+		}
+	}
+
+	if quoted {
+		return nil, ierror.FmtU("value '%s' contains an unterminated quote", src)
+	}
+
+	return append(elems, elem.String()), nil
+}
+
 // =============================================================================
 // Indirect Field
 // › Handles interfaces and pointers
 // =============================================================================
 
-func decodeFieldIndirect(dst reflect.Value, src string) (bool, error) {
+func (fd fieldDecoder) decodeFieldIndirect(dst reflect.Value, src string) (bool, error) {
 	switch dst.Kind() {
 	case reflect.Interface:
-		return true, decodeFieldInterface(dst, src)
+		return true, fd.decodeFieldInterface(dst, src)
 	case reflect.Pointer:
-		return true, decodeFieldPointer(dst, src)
+		if _, ok := fd.known[dst.Type()]; ok {
+			// Known pointer type, eg. *regexp.Regexp
+			// ⇒ Decode the pointer itself
+			return false, nil
+		}
+
+		return true, fd.decodeFieldPointer(dst, src)
 	default:
 		return false, nil
 	}
 }
 
-func decodeFieldInterface(dst reflect.Value, src string) error {
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// Follow pointers from val, allocating any that are nil
+func indirectValue(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			val.Set(reflect.New(val.Type().Elem()))
+		}
+		val = val.Elem()
+	}
+	return val
+}
+
+func (fd fieldDecoder) decodeFieldInterface(dst reflect.Value, src string) error {
 	// Interface already contains a concrete type+value
 	// ⇒ Copy that type+value to attain "settability"
 	// ⇒ Decode into this copy and set `dst` on success
@@ -59,7 +210,7 @@ func decodeFieldInterface(dst reflect.Value, src string) error {
 		elemNew := reflect.New(elemOld.Type()).Elem()
 		elemNew.Set(elemOld)
 
-		if err := decodeField(elemNew, src); err != nil {
+		if err := fd.decodeField(elemNew, src); err != nil {
 			return err
 		}
 
@@ -81,17 +232,17 @@ func decodeFieldInterface(dst reflect.Value, src string) error {
 	return ierror.NewD("interface destination neither contains a non-zero value nor is string-assignable")
 }
 
-func decodeFieldPointer(dst reflect.Value, src string) error {
+func (fd fieldDecoder) decodeFieldPointer(dst reflect.Value, src string) error {
 	if !dst.IsZero() {
 		// Why bother with this?
 		// ⇒ See tests 'DecodeBaroque/pointer->interface->…'
-		return decodeField(dst.Elem(), src)
+		return fd.decodeField(dst.Elem(), src)
 	}
 
 	elemType := dst.Type().Elem()
 
 	val := reflect.New(elemType)
-	if err := decodeField(val.Elem(), src); err != nil {
+	if err := fd.decodeField(val.Elem(), src); err != nil {
 		return err
 	}
 
@@ -99,6 +250,43 @@ func decodeFieldPointer(dst reflect.Value, src string) error {
 	return nil
 }
 
+// =============================================================================
+// Choices
+// › Handles `UkaseChoices() []string` implementations
+// › Restricts input to the listed values prior to any further decoding
+// =============================================================================
+
+type chooser interface{ UkaseChoices() []string }
+
+func checkFieldChoices(dst reflect.Value, src string) error {
+	x, ok := reflect.New(dst.Type()).Interface().(chooser)
+	if !ok {
+		return nil
+	}
+
+	choices := x.UkaseChoices()
+	if slices.Contains(choices, src) {
+		return nil
+	}
+
+	return newInvalidChoiceError(src, choices)
+}
+
+// =============================================================================
+// Known Field
+// › Handles well-known and registered types by exact type, eg. time.Duration
+// › Takes precedence over encoding.TextUnmarshaler and kind based decoding
+// =============================================================================
+
+func (fd fieldDecoder) decodeFieldKnown(dst reflect.Value, src string) (bool, error) {
+	decode, ok := fd.known[dst.Type()]
+	if !ok {
+		return false, nil
+	}
+
+	return true, decode(dst, src)
+}
+
 // =============================================================================
 // Custom Field
 // › Handles encoding.TextUnmarshaler implementations
@@ -144,12 +332,12 @@ func loadTextUnmarshaler(val reflect.Value) (encoding.TextUnmarshaler, bool) {
 
 // =============================================================================
 // Direct Field
-// › Handles slices
+// › Handles slices, arrays and maps
 // › Handles "basic" types (bool, numeric, string)
 //
 // TODO:
-// Move slices into their own "Container Type" section?
-// Esp. if support for arrays (or even structs) is added
+// Move containers into their own "Container Type" section?
+// Esp. if support for structs is added
 // =============================================================================
 
 var basicDecoders = map[reflect.Kind]func(reflect.Value, string) error{
@@ -171,28 +359,33 @@ var basicDecoders = map[reflect.Kind]func(reflect.Value, string) error{
 	reflect.String:     decodeString,
 }
 
-func decodeFieldDirect(dst reflect.Value, src string) (bool, error) {
+func (fd fieldDecoder) decodeFieldDirect(dst reflect.Value, src string) (bool, error) {
 	kind := dst.Kind()
 
 	if kind == reflect.Slice {
-		return true, decodeSlice(dst, src)
+		return true, fd.decodeSlice(dst, src)
+	}
+
+	if kind == reflect.Array {
+		return true, fd.decodeArray(dst, []string{src})
+	}
+
+	if kind == reflect.Map {
+		return true, fd.decodeMap(dst, src)
 	}
 
 	if decodeBasic, ok := basicDecoders[kind]; ok {
-		// TODO:
-		// The "user" errors coming from here are not "pretty" enough for actual end-users
-		// example > strconv.ParseBool: parsing "ipsum": invalid syntax
 		return true, decodeBasic(dst, src)
 	}
 
 	return false, nil
 }
 
-func decodeSlice(dst reflect.Value, src string) error {
+func (fd fieldDecoder) decodeSlice(dst reflect.Value, src string) error {
 	elemType := dst.Type().Elem()
 	elemVal := reflect.New(elemType).Elem()
 
-	if err := decodeField(elemVal, src); err != nil {
+	if err := fd.decodeField(elemVal, src); err != nil {
 		return err
 	}
 
@@ -201,10 +394,62 @@ func decodeSlice(dst reflect.Value, src string) error {
 	return nil
 }
 
+// Array values are decoded from exactly as many elements as the array length,
+// ie. a single value, a split value or values gathered from repeated flags
+func (fd fieldDecoder) decodeArray(dst reflect.Value, elems []string) error {
+	if len(elems) != dst.Len() {
+		return ierror.FmtU("expected %d array elements, got %d", dst.Len(), len(elems))
+	}
+
+	elemType := dst.Type().Elem()
+
+	for i, elem := range elems {
+		elemVal := reflect.New(elemType).Elem()
+		if err := fd.decodeField(elemVal, elem); err != nil {
+			return err
+		}
+
+		dst.Index(i).Set(elemVal)
+	}
+
+	return nil
+}
+
+// Map values are "key=value" pairs, split on the first '='. Values for an
+// existing key are decoded over the existing value, so that slice values
+// accumulate and all others are replaced.
+func (fd fieldDecoder) decodeMap(dst reflect.Value, src string) error {
+	keySrc, elemSrc, ok := strings.Cut(src, "=")
+	if !ok {
+		return ierror.FmtU("map entry '%s' is missing a '=' separator", src)
+	}
+
+	keyVal := reflect.New(dst.Type().Key()).Elem()
+	if err := fd.decodeField(keyVal, keySrc); err != nil {
+		return err
+	}
+
+	elemVal := reflect.New(dst.Type().Elem()).Elem()
+	if existing := dst.MapIndex(keyVal); existing.IsValid() {
+		elemVal.Set(existing)
+	}
+
+	if err := fd.decodeField(elemVal, elemSrc); err != nil {
+		return err
+	}
+
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMap(dst.Type()))
+	}
+
+	dst.SetMapIndex(keyVal, elemVal)
+	return nil
+}
+
 func decodeBool(dst reflect.Value, src string) error {
 	boolVal, err := strconv.ParseBool(src)
 	if err != nil {
-		return ierror.U(err)
+		return newInvalidValueError(dst.Type(), src, "a boolean (true or false)", err)
 	}
 
 	dst.SetBool(boolVal)
@@ -212,29 +457,44 @@ func decodeBool(dst reflect.Value, src string) error {
 }
 
 func decodeInt(dst reflect.Value, src string) error {
-	intVal, err := strconv.ParseInt(src, 10, dst.Type().Bits())
-	if err != nil {
-		return ierror.U(err)
+	bits := dst.Type().Bits()
+
+	intVal, err := strconv.ParseInt(src, 10, bits)
+	if err == nil {
+		dst.SetInt(intVal)
+		return nil
 	}
 
-	dst.SetInt(intVal)
-	return nil
+	if bits == 64 || dst.Kind() == reflect.Int {
+		return newInvalidValueError(dst.Type(), src, "an integer", err)
+	}
+
+	min := strconv.FormatInt(-1<<(bits-1), 10)
+	max := strconv.FormatInt(1<<(bits-1)-1, 10)
+	return newInvalidValueErrorRange(dst.Type(), src, min, max, err)
 }
 
 func decodeUint(dst reflect.Value, src string) error {
-	uintVal, err := strconv.ParseUint(src, 10, dst.Type().Bits())
-	if err != nil {
-		return ierror.U(err)
+	bits := dst.Type().Bits()
+
+	uintVal, err := strconv.ParseUint(src, 10, bits)
+	if err == nil {
+		dst.SetUint(uintVal)
+		return nil
 	}
 
-	dst.SetUint(uintVal)
-	return nil
+	if bits == 64 || dst.Kind() == reflect.Uint {
+		return newInvalidValueError(dst.Type(), src, "a non-negative integer", err)
+	}
+
+	max := strconv.FormatUint(1<<bits-1, 10)
+	return newInvalidValueErrorRange(dst.Type(), src, "0", max, err)
 }
 
 func decodeFloat(dst reflect.Value, src string) error {
 	floatVal, err := strconv.ParseFloat(src, dst.Type().Bits())
 	if err != nil {
-		return ierror.U(err)
+		return newInvalidValueError(dst.Type(), src, "a number", err)
 	}
 
 	dst.SetFloat(floatVal)
@@ -244,13 +504,19 @@ func decodeFloat(dst reflect.Value, src string) error {
 func decodeComplex(dst reflect.Value, src string) error {
 	complexVal, err := strconv.ParseComplex(src, dst.Type().Bits())
 	if err != nil {
-		return ierror.U(err)
+		return newInvalidValueError(dst.Type(), src, "a complex number", err)
 	}
 
 	dst.SetComplex(complexVal)
 	return nil
 }
 
+// Sized (ie. 8, 16 and 32 bit) integers report their range as expected
+func newInvalidValueErrorRange(t reflect.Type, src, min, max string, cause error) error {
+	expected := fmt.Sprintf("an integer between %s and %s", min, max)
+	return InvalidValueError{Type: t, Value: src, Expected: expected, Min: min, Max: max, err: ierror.U(cause)}
+}
+
 func decodeString(dst reflect.Value, src string) error {
 	dst.SetString(src)
 	return nil
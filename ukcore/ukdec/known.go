@@ -0,0 +1,95 @@
+package ukdec
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"time"
+)
+
+// =============================================================================
+// Registered Decoders
+// =============================================================================
+
+// DecodeFunc decodes src into the settable dst
+type DecodeFunc func(dst reflect.Value, src string) error
+
+var _ Option = registerOption{}
+
+type registerOption struct {
+	typ    reflect.Type
+	decode DecodeFunc
+}
+
+// RegisterDecoder adds a decoder for fields of exact type T, taking precedence
+// over both built-in and encoding.TextUnmarshaler decoding. Pointers to T are
+// handled by indirection, so T should not itself be a pointer type.
+func RegisterDecoder[T any](parse func(string) (T, error)) Option {
+	return registerOption{typ: reflect.TypeFor[T](), decode: knownDecoder(parse, "")}
+}
+
+func (o registerOption) UkaseApplyDec(c *Config) {
+	// Clone prior to insertion, configs may share a map by copy
+	decoders := maps.Clone(c.Decoders)
+	if decoders == nil {
+		decoders = make(map[reflect.Type]DecodeFunc)
+	}
+
+	decoders[o.typ] = o.decode
+	c.Decoders = decoders
+}
+
+// =============================================================================
+// Known Decoders
+// › Well-known types lacking an appropriate encoding.TextUnmarshaler, or whose
+//   kind based decoding would surprise, eg. time.Duration as nanoseconds
+// › Pointer types are handled by indirection, so value types are listed except
+//   where only a pointer is meaningful, eg. *regexp.Regexp
+// =============================================================================
+
+func knownDecoders(config Config) map[reflect.Type]DecodeFunc {
+	timeExpected := fmt.Sprintf("a time formatted as '%s'", config.TimeLayout)
+
+	known := map[reflect.Type]DecodeFunc{
+		reflect.TypeFor[time.Duration]():  knownDecoder(time.ParseDuration, "a duration (eg. 1m30s)"),
+		reflect.TypeFor[time.Time]():      knownDecoder(parseTime(config.TimeLayout), timeExpected),
+		reflect.TypeFor[url.URL]():        knownDecoder(parseURL, "a URL"),
+		reflect.TypeFor[netip.Addr]():     knownDecoder(netip.ParseAddr, "an IP address"),
+		reflect.TypeFor[netip.AddrPort](): knownDecoder(netip.ParseAddrPort, "an IP address and port"),
+		reflect.TypeFor[netip.Prefix]():   knownDecoder(netip.ParsePrefix, "an IP prefix (eg. 10.0.0.0/8)"),
+		reflect.TypeFor[*regexp.Regexp](): knownDecoder(regexp.Compile, ""),
+	}
+
+	// Registered decoders take precedence
+	maps.Copy(known, config.Decoders)
+	return known
+}
+
+// An empty expected description defers to the parse error when failing, as
+// is appropriate for errors more informative than the type, eg. regexp syntax
+func knownDecoder[T any](parse func(string) (T, error), expected string) DecodeFunc {
+	return func(dst reflect.Value, src string) error {
+		val, err := parse(src)
+		if err != nil {
+			return newInvalidValueError(dst.Type(), src, expected, err)
+		}
+
+		dst.Set(reflect.ValueOf(val))
+		return nil
+	}
+}
+
+func parseTime(layout string) func(string) (time.Time, error) {
+	return func(s string) (time.Time, error) { return time.Parse(layout, s) }
+}
+
+func parseURL(s string) (url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return url.URL{}, err
+	}
+	return *u, nil
+}
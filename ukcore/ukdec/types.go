@@ -0,0 +1,117 @@
+package ukdec
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+)
+
+// =============================================================================
+// Counter
+// =============================================================================
+
+// Counter tallies flag occurrences, eg. "-vvv" ⇒ 3. Each true (or elided)
+// value increments the count, a false value resets it and an integer value
+// assigns it directly.
+type Counter int
+
+func (Counter) UkaseElide() bool { return true }
+
+func (c *Counter) UnmarshalText(text []byte) error {
+	s := string(text)
+
+	if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+		*c = Counter(n)
+		return nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return ierror.FmtU("invalid count '%s'", s)
+	}
+
+	if b {
+		*c++
+	} else {
+		*c = 0
+	}
+
+	return nil
+}
+
+// =============================================================================
+// ByteSize
+// =============================================================================
+
+// ByteSize is a count of bytes given in human friendly form, eg. "10MiB" or
+// "1.5GB". Decimal (kB, MB, …) and binary (KiB, MiB, …) units are accepted
+// case insensitively, a bare number is a count of bytes.
+type ByteSize uint64
+
+var byteSizeUnits = []struct {
+	name string
+	size uint64
+}{
+	{"EiB", 1 << 60}, {"PiB", 1 << 50}, {"TiB", 1 << 40}, {"GiB", 1 << 30}, {"MiB", 1 << 20}, {"KiB", 1 << 10},
+	{"EB", 1e18}, {"PB", 1e15}, {"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3},
+	{"B", 1},
+}
+
+func (bs ByteSize) String() string {
+	// Binary units are listed first, largest first
+	// ⇒ Use the largest binary unit dividing the size exactly
+	for _, unit := range byteSizeUnits[:6] {
+		if bs != 0 && uint64(bs)%unit.size == 0 {
+			return strconv.FormatUint(uint64(bs)/unit.size, 10) + unit.name
+		}
+	}
+
+	return strconv.FormatUint(uint64(bs), 10) + "B"
+}
+
+func (bs ByteSize) MarshalText() ([]byte, error) { return []byte(bs.String()), nil }
+
+func (bs *ByteSize) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	numStr := strings.TrimRightFunc(s, unicode.IsLetter)
+	unitStr := s[len(numStr):]
+	numStr = strings.TrimSpace(numStr)
+
+	size, ok := uint64(1), unitStr == ""
+	for _, unit := range byteSizeUnits {
+		if strings.EqualFold(unit.name, unitStr) {
+			size, ok = unit.size, true
+			break
+		}
+	}
+
+	if !ok {
+		return ierror.FmtU("invalid byte size '%s' (unknown unit '%s')", s, unitStr)
+	}
+
+	// Integer sizes are handled exactly, fractional sizes are rounded down
+	if n, err := strconv.ParseUint(numStr, 10, 64); err == nil {
+		if n > math.MaxUint64/size {
+			return ierror.FmtU("invalid byte size '%s' (out of range)", s)
+		}
+
+		*bs = ByteSize(n * size)
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(numStr, 64)
+	if err != nil || f < 0 || math.IsNaN(f) {
+		return ierror.FmtU("invalid byte size '%s'", s)
+	}
+
+	if f *= float64(size); f >= math.MaxUint64 {
+		return ierror.FmtU("invalid byte size '%s' (out of range)", s)
+	}
+
+	*bs = ByteSize(f)
+	return nil
+}
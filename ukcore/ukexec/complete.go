@@ -0,0 +1,118 @@
+package ukexec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+)
+
+// =============================================================================
+// Completion
+// =============================================================================
+
+type CompleteKind int
+
+const (
+	// Cursor is on a subcommand name or the 1st argument
+	CompleteCommand CompleteKind = iota
+
+	// Cursor is on a flag name
+	CompleteFlagName
+
+	// Cursor is on the value of a flag
+	CompleteFlagValue
+
+	// Cursor is on an argument
+	CompleteArgument
+)
+
+var completeKindToString = map[CompleteKind]string{
+	CompleteCommand:   "COMMAND",
+	CompleteFlagName:  "FLAG_NAME",
+	CompleteFlagValue: "FLAG_VALUE",
+	CompleteArgument:  "ARGUMENT",
+}
+
+func (ck CompleteKind) String() string {
+	if str, ok := completeKindToString[ck]; ok {
+		return str
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", ck)
+}
+
+type Completion struct {
+	Kind   CompleteKind
+	Prefix string
+	Target []string
+	Meta   Meta
+
+	// Flag names accepted at the resolved target
+	FlagNames []string
+
+	// Flag awaiting a value, valid for ❬FlagValue❭
+	Flag ukspec.Flag
+
+	// Argument position under the cursor, valid for ❬Command❭ and ❬Argument❭
+	Position int
+}
+
+// Complete parses a partial command line, tolerating malformed and unknown
+// flags, and describes the context of the final value (the cursor).
+func (m *Mux) Complete(values []string) (Completion, error) {
+	if len(values) == 0 {
+		return Completion{}, ErrorParse{err: ErrMissingProgram}
+	}
+
+	// Program only ⇒ cursor on an empty value
+	if len(values) == 1 {
+		values = append(slices.Clip(values), "")
+	}
+
+	prefix := values[len(values)-1]
+	parser := &parser{Values: values[:len(values)-1], Tolerant: true}
+
+	input, node, err := m.parse(parser)
+	if err != nil {
+		return Completion{}, err
+	}
+
+	completion := Completion{
+		Prefix:    prefix,
+		Target:    input.Target,
+		Meta:      newMeta(node),
+		FlagNames: m.flagNames(node),
+		Position:  len(input.Arguments),
+	}
+
+	switch {
+	case parser.Pending != "":
+		completion.Kind = CompleteFlagValue
+		completion.Flag = node.flags[parser.Pending]
+	case parser.Delim:
+		completion.Kind = CompleteArgument
+	case strings.HasPrefix(prefix, "-") && (m.config.Interspersed || len(input.Arguments) == 0):
+		completion.Kind = CompleteFlagName
+	case len(input.Arguments) > 0:
+		completion.Kind = CompleteArgument
+	default:
+		completion.Kind = CompleteCommand
+	}
+
+	m.config.Log.Info("completing", "target", input.Target, "kind", completion.Kind)
+
+	return completion, nil
+}
+
+func (Mux) flagNames(node *muxNode) []string {
+	names := make([]string, 0, len(node.flags))
+	for name, spec := range node.flags {
+		if !spec.Hidden && !spec.IsDeprecated(name) {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+	return names
+}
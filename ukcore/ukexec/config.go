@@ -33,6 +33,12 @@ type Config struct {
 
 	// TODO: Document
 	FlagConflict func(original, update ukspec.Flag) error
+
+	// TODO: Document
+	Interspersed bool
+
+	// TODO: Document
+	WarnDeprecated func(ctx context.Context, name, canonical string)
 }
 
 func newConfig(opts []Option) Config {
@@ -53,6 +59,7 @@ var cfgDefault = Config{
 	ExecConflict:    cfgExecConflict,
 	InfoConflict:    cfgInfoConflict,
 	FlagConflict:    cfgFlagConflict,
+	WarnDeprecated:  cfgWarnDeprecated,
 }
 
 func cfgExecUnspecified(_ context.Context, i ukcore.Input) error {
@@ -67,6 +74,8 @@ func cfgInfoConflict(_, _ any) (bool, error) {
 	return false, errors.New("info already exists")
 }
 
+func cfgWarnDeprecated(context.Context, string, string) {}
+
 func cfgFlagConflict(o, u ukspec.Flag) error {
 	if o.Elide.Allow != u.Elide.Allow {
 		return fmt.Errorf("incompatible elide behavior '%t' and '%t'", o.Elide.Allow, u.Elide.Allow)
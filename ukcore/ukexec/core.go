@@ -5,9 +5,12 @@ import "github.com/oligarch316/ukase/ukcore/ukspec"
 var paramsSpecEmpty, _ = ukspec.ParametersFor[struct{}]()
 
 type Meta struct {
-	Exec bool
-	Info any
-	Spec ukspec.Parameters
+	Exec    bool
+	Hidden  bool
+	Meta    bool
+	Info    any
+	Spec    ukspec.Parameters
+	Aliases []string
 
 	children map[string]*muxNode
 }
@@ -15,8 +18,11 @@ type Meta struct {
 func newMeta(node *muxNode) Meta {
 	meta := Meta{
 		Exec:     node.exec != nil,
+		Hidden:   node.hidden,
+		Meta:     node.meta,
 		Info:     nil,
 		Spec:     paramsSpecEmpty,
+		Aliases:  node.alternates,
 		children: node.children,
 	}
 
@@ -3,13 +3,21 @@ package ukexec
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/oligarch316/ukase/internal/ierror"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
 )
 
 var (
 	ErrTargetNotExist = errors.New("target does not exist")
 	ErrMissingProgram = errors.New("missing program name")
+	ErrInvalidFlag    = errors.New("invalid flag")
+	ErrMalformedFlag  = errors.New("malformed flag")
+	ErrMissingValue   = errors.New("missing value")
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrAliasRoot      = errors.New("root command cannot be aliased")
+	ErrAliasExists    = errors.New("name already in use")
 )
 
 type ErrorExecConflict struct {
@@ -31,9 +39,16 @@ type ErrorFlagConflict struct {
 	err              error
 }
 
-func (eec ErrorExecConflict) Unwrap() error { return eec.err }
-func (eic ErrorInfoConflict) Unwrap() error { return eic.err }
-func (efc ErrorFlagConflict) Unwrap() error { return efc.err }
+type ErrorAliasConflict struct {
+	Target []string
+	Name   string
+	err    error
+}
+
+func (eec ErrorExecConflict) Unwrap() error  { return eec.err }
+func (eic ErrorInfoConflict) Unwrap() error  { return eic.err }
+func (efc ErrorFlagConflict) Unwrap() error  { return efc.err }
+func (eac ErrorAliasConflict) Unwrap() error { return eac.err }
 
 func (efc ErrorFlagConflict) Error() string {
 	return fmt.Sprintf(
@@ -42,6 +57,13 @@ func (efc ErrorFlagConflict) Error() string {
 	)
 }
 
+func (eac ErrorAliasConflict) Error() string {
+	return fmt.Sprintf(
+		"conflicting alias '%s' for target '%s': %s",
+		eac.Name, eac.Target, eac.err,
+	)
+}
+
 func (eec ErrorExecConflict) Error() string {
 	return fmt.Sprintf(
 		"conflicting exec specifications for target '%s': %s",
@@ -57,10 +79,68 @@ func (eic ErrorInfoConflict) Error() string {
 }
 
 type ErrorParse struct {
-	Target   []string
-	Position int
-	err      error
+	Target      []string
+	Position    int
+	Suggestions []string
+	err         error
 }
 
-func (ep ErrorParse) Unwrap() error { return ep.err }
-func (ep ErrorParse) Error() string { return ep.err.Error() }
+func newErrorParse(target []string, position int, err error) ErrorParse {
+	ep := ErrorParse{Target: target, Position: position, err: err}
+
+	var se suggestError
+	if errors.As(err, &se) {
+		ep.Suggestions = se.suggestions
+	}
+
+	return ep
+}
+
+type ErrorUnknownCommand struct {
+	Target      []string
+	Position    int
+	Name        string
+	Suggestions []string
+	err         error
+}
+
+func newErrorUnknownCommand(target []string, position int, name string, suggestions []string) ErrorUnknownCommand {
+	err := newSuggestError(ierror.FmtU("%w '%s'", ErrUnknownCommand, name), suggestions)
+	return ErrorUnknownCommand{Target: target, Position: position, Name: name, Suggestions: suggestions, err: err}
+}
+
+var errIsTagged = ierror.IsTaggedFunc(ierror.ErrExec)
+
+func (ep ErrorParse) Is(t error) bool           { return errIsTagged(t) }
+func (euc ErrorUnknownCommand) Is(t error) bool { return errIsTagged(t) }
+
+func (ep ErrorParse) Unwrap() error           { return ep.err }
+func (euc ErrorUnknownCommand) Unwrap() error { return euc.err }
+
+func (ep ErrorParse) Error() string           { return ep.err.Error() }
+func (euc ErrorUnknownCommand) Error() string { return euc.err.Error() }
+
+// Decorate an error message with "did you mean" suggestions, retaining them
+// for `ErrorParse` and `ErrorUnknownCommand`
+type suggestError struct {
+	suggestions []string
+	err         error
+}
+
+func newSuggestError(err error, suggestions []string) error {
+	if len(suggestions) == 0 {
+		return err
+	}
+	return suggestError{suggestions: suggestions, err: err}
+}
+
+func (se suggestError) Unwrap() error { return se.err }
+
+func (se suggestError) Error() string {
+	quoted := make([]string, len(se.suggestions))
+	for i, suggestion := range se.suggestions {
+		quoted[i] = "'" + suggestion + "'"
+	}
+
+	return fmt.Sprintf("%s, did you mean %s?", se.err, strings.Join(quoted, " or "))
+}
@@ -22,21 +22,46 @@ func New(opts ...Option) *Mux {
 }
 
 type muxNode struct {
-	exec ukcore.Exec
-	info any
-	spec *ukspec.Parameters
+	exec   ukcore.Exec
+	info   any
+	spec   *ukspec.Parameters
+	hidden bool
+	meta   bool
+
+	// Non-deprecated alias names by which this node is known to its parent
+	alternates []string
 
 	children map[string]*muxNode
+	aliases  map[string]muxAlias
 	flags    map[string]ukspec.Flag
 }
 
+type muxAlias struct {
+	Canonical  string
+	Deprecated bool
+}
+
 func newMuxNode() *muxNode {
 	return &muxNode{
 		children: make(map[string]*muxNode),
+		aliases:  make(map[string]muxAlias),
 		flags:    make(map[string]ukspec.Flag),
 	}
 }
 
+// Resolve a child by canonical name or alias
+func (n *muxNode) lookupChild(name string) (*muxNode, muxAlias, bool) {
+	if child, ok := n.children[name]; ok {
+		return child, muxAlias{Canonical: name}, true
+	}
+
+	if alias, ok := n.aliases[name]; ok {
+		return n.children[alias.Canonical], alias, true
+	}
+
+	return nil, muxAlias{}, false
+}
+
 // =============================================================================
 // Write
 // =============================================================================
@@ -44,6 +69,10 @@ func newMuxNode() *muxNode {
 func (m *Mux) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...string) error {
 	m.config.Log.Debug("registering exec", "target", target, "specType", spec.Type)
 
+	if node, ok := m.lookupNode(target); ok && node.meta {
+		return m.registerMetaExec(node, target, exec, spec)
+	}
+
 	if err := m.validateFlags(m.root, target, spec.Flags); err != nil {
 		return err
 	}
@@ -52,7 +81,7 @@ func (m *Mux) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...s
 	m.updateFlags(node, spec.Flags)
 
 	for _, name := range target {
-		child, ok := node.children[name]
+		child, _, ok := node.lookupChild(name)
 		if !ok {
 			child = newMuxNode()
 			node.children[name] = child
@@ -65,13 +94,24 @@ func (m *Mux) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...s
 	return m.updateExec(node, target, exec, spec)
 }
 
+// Meta commands stand apart from the application they describe
+// ⇒ Keep their flags on the meta node alone, out of ancestors' flag namespace
+func (m *Mux) registerMetaExec(node *muxNode, target []string, exec ukcore.Exec, spec ukspec.Parameters) error {
+	if err := m.validateFlags(node, target, spec.Flags); err != nil {
+		return err
+	}
+
+	m.updateFlags(node, spec.Flags)
+	return m.updateExec(node, target, exec, spec)
+}
+
 func (m *Mux) RegisterInfo(info any, target ...string) error {
 	m.config.Log.Debug("registering info", "target", target, "infoType", fmt.Sprintf("%T", info))
 
 	node := m.root
 
 	for _, name := range target {
-		child, ok := node.children[name]
+		child, _, ok := node.lookupChild(name)
 		if !ok {
 			child = newMuxNode()
 			node.children[name] = child
@@ -83,6 +123,101 @@ func (m *Mux) RegisterInfo(info any, target ...string) error {
 	return m.updateInfo(node, target, info)
 }
 
+func (m *Mux) RegisterHidden(target ...string) error {
+	m.config.Log.Debug("registering hidden", "target", target)
+
+	m.ensureNode(target).hidden = true
+	return nil
+}
+
+// RegisterMeta marks the target as a meta command, ie. one describing or
+// supporting the application (help, completion, …) rather than part of it.
+// Flags of an exec registered to the target after marking are not added to
+// those of its ancestors.
+func (m *Mux) RegisterMeta(target ...string) error {
+	m.config.Log.Debug("registering meta", "target", target)
+
+	m.ensureNode(target).meta = true
+	return nil
+}
+
+// Resolve the node at target, creating any missing nodes along the way
+func (m *Mux) ensureNode(target []string) *muxNode {
+	node := m.root
+
+	for _, name := range target {
+		child, _, ok := node.lookupChild(name)
+		if !ok {
+			child = newMuxNode()
+			node.children[name] = child
+		}
+
+		node = child
+	}
+
+	return node
+}
+
+// Resolve the node at target, if it exists
+func (m *Mux) lookupNode(target []string) (*muxNode, bool) {
+	node := m.root
+
+	for _, name := range target {
+		child, _, ok := node.lookupChild(name)
+		if !ok {
+			return nil, false
+		}
+
+		node = child
+	}
+
+	return node, true
+}
+
+func (m *Mux) RegisterAlias(name string, target ...string) error {
+	m.config.Log.Debug("registering alias", "target", target, "name", name)
+	return m.updateAlias(name, false, target)
+}
+
+func (m *Mux) RegisterDeprecated(name string, target ...string) error {
+	m.config.Log.Debug("registering deprecated alias", "target", target, "name", name)
+	return m.updateAlias(name, true, target)
+}
+
+func (m *Mux) updateAlias(name string, deprecated bool, target []string) error {
+	if len(target) == 0 {
+		return ErrorAliasConflict{Target: target, Name: name, err: ErrAliasRoot}
+	}
+
+	parent, node := m.root, m.root
+	var alias muxAlias
+
+	for _, targetName := range target {
+		child, childAlias, ok := node.lookupChild(targetName)
+		if !ok {
+			child, childAlias = newMuxNode(), muxAlias{Canonical: targetName}
+			node.children[targetName] = child
+		}
+
+		parent, node, alias = node, child, childAlias
+	}
+
+	if existing, _, ok := parent.lookupChild(name); ok {
+		if existing == node {
+			return nil
+		}
+		return ErrorAliasConflict{Target: target, Name: name, err: ErrAliasExists}
+	}
+
+	parent.aliases[name] = muxAlias{Canonical: alias.Canonical, Deprecated: deprecated}
+
+	if !deprecated {
+		node.alternates = append(node.alternates, name)
+	}
+
+	return nil
+}
+
 func (m *Mux) updateExec(node *muxNode, target []string, exec ukcore.Exec, spec ukspec.Parameters) error {
 	if node.spec == nil {
 		node.exec, node.spec = exec, &spec
@@ -152,15 +287,9 @@ func (m *Mux) validateFlags(node *muxNode, target []string, flags []ukspec.Flag)
 // =============================================================================
 
 func (m *Mux) Meta(target ...string) (Meta, error) {
-	node := m.root
-
-	for _, name := range target {
-		child, ok := node.children[name]
-		if !ok {
-			return Meta{}, fmt.Errorf("invalid target '%s': %w", target, ErrTargetNotExist)
-		}
-
-		node = child
+	node, ok := m.lookupNode(target)
+	if !ok {
+		return Meta{}, fmt.Errorf("invalid target '%s': %w", target, ErrTargetNotExist)
 	}
 
 	return newMeta(node), nil
@@ -169,9 +298,29 @@ func (m *Mux) Meta(target ...string) (Meta, error) {
 func (m *Mux) Execute(ctx context.Context, values []string) error {
 	parser := newParser(values)
 
+	input, node, err := m.parse(parser)
+	if err != nil {
+		return err
+	}
+
+	for _, deprecated := range parser.Deprecated {
+		m.config.Log.Warn("deprecated command", "name", deprecated.Name, "canonical", deprecated.Canonical)
+		m.config.WarnDeprecated(ctx, deprecated.Name, deprecated.Canonical)
+	}
+
+	m.config.Log.Info("executing", "target", input.Target)
+
+	if node.exec == nil {
+		return m.config.ExecUnspecified(ctx, input)
+	}
+
+	return node.exec(ctx, input)
+}
+
+func (m *Mux) parse(parser *parser) (ukcore.Input, *muxNode, error) {
 	program, ok := parser.ConsumeValue()
 	if !ok {
-		return ErrorParse{err: ErrMissingProgram}
+		return ukcore.Input{}, nil, ErrorParse{err: ErrMissingProgram}
 	}
 
 	input := ukcore.Input{Program: program}
@@ -181,7 +330,7 @@ func (m *Mux) Execute(ctx context.Context, values []string) error {
 		// Consume all flags for the current node
 		flags, err := parser.ConsumeFlags(node.flags)
 		if err != nil {
-			return ErrorParse{Target: input.Target, Position: parser.Position, err: err}
+			return input, node, newErrorParse(input.Target, parser.Position, err)
 		}
 
 		input.Flags = append(input.Flags, flags...)
@@ -191,31 +340,91 @@ func (m *Mux) Execute(ctx context.Context, values []string) error {
 
 		// ... ❬Delim❭ or ❬EOF❭ ⇒ break out to argument parsing
 		if token.Kind == kindDelim || token.Kind == kindEOF {
+			parser.Delim = token.Kind == kindDelim
 			break
 		}
 
 		// ... non-subcommand ⇒ set as 1st argument and break out to argument parsing
-		child, ok := node.children[token.Value]
+		child, alias, ok := node.lookupChild(token.Value)
 		if !ok {
+			if m.unknownCommand(parser, node) {
+				suggestions := suggestCommands(node, token.Value)
+				return input, node, newErrorUnknownCommand(input.Target, parser.Position-1, token.Value, suggestions)
+			}
+
 			input.Arguments = m.appendArguments(input.Arguments, token.Value)
 			break
 		}
 
-		// ... subcommand ⇒ append command name to target and continue
-		input.Target = append(input.Target, token.Value)
+		// ... deprecated alias ⇒ note for warning prior to execution
+		if alias.Deprecated {
+			parser.Deprecated = append(parser.Deprecated, deprecation{Name: token.Value, Canonical: alias.Canonical})
+		}
+
+		// ... subcommand ⇒ append (canonical) command name to target and continue
+		input.Target = append(input.Target, alias.Canonical)
 		node = child
 	}
 
+	if m.config.Interspersed && !parser.Delim && len(input.Arguments) > 0 {
+		return m.parseInterspersed(parser, input, node)
+	}
+
 	// All remaining unconsumed values are treated as arguments
 	input.Arguments = m.appendArguments(input.Arguments, parser.Values...)
 
-	m.config.Log.Info("executing", "target", input.Target)
+	return input, node, nil
+}
 
-	if node.exec == nil {
-		return m.config.ExecUnspecified(ctx, input)
+func (m *Mux) parseInterspersed(parser *parser, input ukcore.Input, node *muxNode) (ukcore.Input, *muxNode, error) {
+	parser.Arguments = true
+
+	for {
+		// Consume all flags for the resolved node
+		flags, err := parser.ConsumeFlags(node.flags)
+		if err != nil {
+			return input, node, newErrorParse(input.Target, parser.Position, err)
+		}
+
+		input.Flags = append(input.Flags, flags...)
+
+		// Consume the next token of kind ...
+		token := parser.ConsumeToken()
+
+		// ... ❬EOF❭ ⇒ done
+		if token.Kind == kindEOF {
+			return input, node, nil
+		}
+
+		// ... ❬Delim❭ ⇒ break out to remaining argument parsing
+		if token.Kind == kindDelim {
+			parser.Delim = true
+			break
+		}
+
+		// ... ❬String❭ or ❬Empty❭ ⇒ append as argument and continue
+		input.Arguments = m.appendArguments(input.Arguments, token.Value)
 	}
 
-	return node.exec(ctx, input)
+	// All remaining unconsumed values are treated as arguments
+	input.Arguments = m.appendArguments(input.Arguments, parser.Values...)
+
+	return input, node, nil
+}
+
+// A non-subcommand value is treated as an unknown command, rather than as the
+// 1st argument, when the node has subcommands but does not accept arguments
+func (Mux) unknownCommand(parser *parser, node *muxNode) bool {
+	if parser.Tolerant || len(node.children) == 0 {
+		return false
+	}
+
+	if node.exec == nil || node.spec == nil {
+		return true
+	}
+
+	_, accepted := node.spec.LookupArgument(0)
+	return !accepted
 }
 
 func (Mux) appendArguments(args []ukcore.Argument, values ...string) []ukcore.Argument {
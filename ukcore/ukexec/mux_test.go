@@ -0,0 +1,497 @@
+package ukexec_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/oligarch316/ukase/internal/itest"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukexec"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type paramsRoot struct {
+	Verbose bool `ukflag:"v verbose"`
+}
+
+type paramsCopy struct {
+	Force bool     `ukflag:"f force"`
+	Mode  string   `ukflag:"m mode"`
+	Files []string `ukarg:":"`
+}
+
+type execOption func(*ukexec.Config)
+
+func (o execOption) UkaseApplyExec(c *ukexec.Config) { o(c) }
+
+var withInterspersed = execOption(func(c *ukexec.Config) { c.Interspersed = true })
+
+func execNoop(context.Context, ukcore.Input) error { return nil }
+
+func newMux(t *testing.T, opts ...ukexec.Option) *ukexec.Mux {
+	return newMuxExec(t, execNoop, opts...)
+}
+
+func newMuxExec(t *testing.T, exec ukcore.Exec, opts ...ukexec.Option) *ukexec.Mux {
+	mux := ukexec.New(opts...)
+
+	specRoot, err := ukspec.ParametersFor[paramsRoot]()
+	assert.NilError(t, err)
+
+	specCopy, err := ukspec.ParametersFor[paramsCopy]()
+	assert.NilError(t, err)
+
+	assert.NilError(t, mux.RegisterExec(exec, specRoot))
+	assert.NilError(t, mux.RegisterExec(exec, specCopy, "copy"))
+	assert.NilError(t, mux.RegisterExec(exec, specCopy, "deploy", "now"))
+
+	return mux
+}
+
+// =============================================================================
+// Execute
+// =============================================================================
+
+func TestExecuteFlags(t *testing.T) {
+	type subtest struct {
+		name     string
+		values   []string
+		expected []ukcore.Flag
+	}
+
+	var actual ukcore.Input
+	mux := newMuxExec(t, func(_ context.Context, in ukcore.Input) error { actual = in; return nil })
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual = ukcore.Input{}
+		err := mux.Execute(context.Background(), st.values)
+
+		return st.name, itest.CmpSequence(
+			cmp.Nil(err),
+			cmp.DeepEqual(actual.Flags, st.expected),
+		)
+	}
+
+	flag := func(name, value string) ukcore.Flag { return ukcore.Flag{Name: name, Value: value} }
+
+	subtests := []subtest{
+		{"short", []string{"prog", "copy", "-m", "x"}, []ukcore.Flag{flag("m", "x")}},
+		{"short inline", []string{"prog", "copy", "-m=x"}, []ukcore.Flag{flag("m", "x")}},
+		{"short attached", []string{"prog", "copy", "-mx"}, []ukcore.Flag{flag("m", "x")}},
+		{"long", []string{"prog", "copy", "--mode", "x"}, []ukcore.Flag{flag("mode", "x")}},
+		{"long inline", []string{"prog", "copy", "--mode=x"}, []ukcore.Flag{flag("mode", "x")}},
+		{"long inline empty", []string{"prog", "copy", "--mode="}, []ukcore.Flag{flag("mode", "")}},
+		{"long inline delimiter", []string{"prog", "copy", "--mode=a=b"}, []ukcore.Flag{flag("mode", "a=b")}},
+		{"elided inline", []string{"prog", "copy", "--force=false"}, []ukcore.Flag{flag("force", "false")}},
+		{"cluster elided", []string{"prog", "-vf", "copy"}, []ukcore.Flag{flag("v", "true"), flag("f", "true")}},
+		{"cluster trailing", []string{"prog", "-vfm", "x", "copy"}, []ukcore.Flag{flag("v", "true"), flag("f", "true"), flag("m", "x")}},
+		{"cluster attached", []string{"prog", "copy", "-fmx"}, []ukcore.Flag{flag("f", "true"), flag("m", "x")}},
+		{"cluster attached inline", []string{"prog", "copy", "-mf=x"}, []ukcore.Flag{flag("m", "f=x")}},
+		{"cluster inline", []string{"prog", "copy", "-fm=x"}, []ukcore.Flag{flag("f", "true"), flag("m", "x")}},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
+func TestExecuteError(t *testing.T) {
+	type subtest struct {
+		name     string
+		values   []string
+		expected error
+		position int
+	}
+
+	mux := newMux(t)
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		err := mux.Execute(context.Background(), st.values)
+
+		var actual ukexec.ErrorParse
+		errors.As(err, &actual)
+
+		return st.name, itest.CmpSequence(
+			itest.CmpErrorAsU[ukexec.ErrorParse](err),
+			itest.CmpErrorIs(err, st.expected),
+			cmp.Equal(actual.Position, st.position),
+		)
+	}
+
+	subtests := []subtest{
+		{"unknown long", []string{"prog", "--lorem"}, ukexec.ErrInvalidFlag, 1},
+		{"unknown short", []string{"prog", "copy", "-q"}, ukexec.ErrInvalidFlag, 2},
+		{"unknown clustered", []string{"prog", "copy", "-fq"}, ukexec.ErrInvalidFlag, 2},
+		{"malformed long", []string{"prog", "copy", "-f", "--x"}, ukexec.ErrMalformedFlag, 3},
+		{"malformed empty name", []string{"prog", "-=x"}, ukexec.ErrMalformedFlag, 1},
+		{"missing value", []string{"prog", "copy", "--mode"}, ukexec.ErrMissingValue, 2},
+		{"missing clustered value", []string{"prog", "copy", "-fm"}, ukexec.ErrMissingValue, 2},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
+func TestExecuteErrorMessage(t *testing.T) {
+	type subtest struct {
+		name     string
+		values   []string
+		expected string
+	}
+
+	mux := newMux(t)
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		err := mux.Execute(context.Background(), st.values)
+		return st.name, cmp.ErrorContains(err, st.expected)
+	}
+
+	subtests := []subtest{
+		{"unknown long", []string{"prog", "--lorem"}, "invalid flag '--lorem'"},
+		{"unknown short", []string{"prog", "copy", "-q"}, "invalid flag '-q'"},
+		{"missing value", []string{"prog", "copy", "--mode"}, "missing value for flag '--mode'"},
+		{"missing short value", []string{"prog", "copy", "-m"}, "missing value for flag '-m'"},
+		{"missing clustered value", []string{"prog", "copy", "-fm"}, "missing value for flag '-m'"},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
+func TestExecuteSuggestions(t *testing.T) {
+	type subtest struct {
+		name     string
+		values   []string
+		expected []string
+	}
+
+	mux := newMux(t)
+
+	runnerFlag := func(st subtest) (string, cmp.Comparison) {
+		err := mux.Execute(context.Background(), st.values)
+
+		var actual ukexec.ErrorParse
+		errors.As(err, &actual)
+
+		return st.name, itest.CmpSequence(
+			itest.CmpErrorAsU[ukexec.ErrorParse](err),
+			itest.CmpErrorIs(err, ukexec.ErrInvalidFlag),
+			cmp.DeepEqual(actual.Suggestions, st.expected),
+		)
+	}
+
+	runnerCommand := func(st subtest) (string, cmp.Comparison) {
+		err := mux.Execute(context.Background(), st.values)
+
+		var actual ukexec.ErrorUnknownCommand
+		errors.As(err, &actual)
+
+		return st.name, itest.CmpSequence(
+			itest.CmpErrorAsU[ukexec.ErrorUnknownCommand](err),
+			itest.CmpErrorIs(err, ukexec.ErrUnknownCommand),
+			cmp.DeepEqual(actual.Suggestions, st.expected),
+		)
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		itest.Runner[subtest](runnerFlag).Run(t,
+			subtest{"transposed", []string{"prog", "--verobse"}, []string{"--verbose"}},
+			subtest{"missing rune", []string{"prog", "copy", "--forc"}, []string{"--force"}},
+			subtest{"distant", []string{"prog", "--lorem"}, nil},
+			subtest{"short", []string{"prog", "-q"}, nil},
+		)
+	})
+
+	t.Run("command", func(t *testing.T) {
+		itest.Runner[subtest](runnerCommand).Run(t,
+			subtest{"transposed", []string{"prog", "depoly"}, []string{"deploy"}},
+			subtest{"nested", []string{"prog", "deploy", "nwo"}, []string{"now"}},
+			subtest{"distant", []string{"prog", "lorem"}, nil},
+		)
+	})
+
+	t.Run("message", func(t *testing.T) {
+		err := mux.Execute(context.Background(), []string{"prog", "cpy"})
+		assert.Check(t, cmp.Error(err, "unknown command 'cpy', did you mean 'copy'?"))
+	})
+
+	t.Run("argument", func(t *testing.T) {
+		err := mux.Execute(context.Background(), []string{"prog", "copy", "cpy"})
+		assert.NilError(t, err)
+	})
+}
+
+func TestExecuteInterspersed(t *testing.T) {
+	type subtest struct {
+		name      string
+		values    []string
+		flags     []ukcore.Flag
+		arguments []string
+	}
+
+	var actual ukcore.Input
+	exec := func(_ context.Context, in ukcore.Input) error { actual = in; return nil }
+
+	runner := func(opts ...ukexec.Option) itest.Runner[subtest] {
+		mux := newMuxExec(t, exec, opts...)
+
+		return func(st subtest) (string, cmp.Comparison) {
+			actual = ukcore.Input{}
+			err := mux.Execute(context.Background(), st.values)
+
+			var arguments []string
+			for _, arg := range actual.Arguments {
+				arguments = append(arguments, arg.Value)
+			}
+
+			return st.name, itest.CmpSequence(
+				cmp.Nil(err),
+				cmp.DeepEqual(actual.Flags, st.flags),
+				cmp.DeepEqual(arguments, st.arguments),
+			)
+		}
+	}
+
+	force := ukcore.Flag{Name: "force", Value: "true"}
+	mode := ukcore.Flag{Name: "m", Value: "x"}
+
+	t.Run("disabled", func(t *testing.T) {
+		runner().Run(t,
+			subtest{"trailing flag", []string{"prog", "copy", "a", "--force"}, nil, []string{"a", "--force"}},
+			subtest{"leading flag", []string{"prog", "copy", "--force", "a"}, []ukcore.Flag{force}, []string{"a"}},
+			subtest{"empty argument", []string{"prog", "copy", "a", "", "--force", "b"}, nil, []string{"a", "", "--force", "b"}},
+		)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		runner(withInterspersed).Run(t,
+			subtest{"trailing flag", []string{"prog", "copy", "a", "--force"}, []ukcore.Flag{force}, []string{"a"}},
+			subtest{"middle flag", []string{"prog", "copy", "a", "--force", "b"}, []ukcore.Flag{force}, []string{"a", "b"}},
+			subtest{"valued flag", []string{"prog", "copy", "a", "-m", "x", "b"}, []ukcore.Flag{mode}, []string{"a", "b"}},
+			subtest{"delimiter", []string{"prog", "copy", "a", "--", "--force"}, nil, []string{"a", "--force"}},
+			subtest{"delimiter after flag", []string{"prog", "copy", "a", "--force", "--", "-m"}, []ukcore.Flag{force}, []string{"a", "-m"}},
+			subtest{"empty argument", []string{"prog", "copy", "a", "", "--force", "b"}, []ukcore.Flag{force}, []string{"a", "", "b"}},
+			subtest{"empty argument after flag", []string{"prog", "copy", "a", "--force", "", "b"}, []ukcore.Flag{force}, []string{"a", "", "b"}},
+		)
+	})
+}
+
+func TestExecuteAliases(t *testing.T) {
+	type subtest struct {
+		name       string
+		values     []string
+		target     []string
+		deprecated []string
+	}
+
+	var (
+		actual     ukcore.Input
+		deprecated []string
+	)
+
+	exec := func(_ context.Context, in ukcore.Input) error { actual = in; return nil }
+	warn := execOption(func(c *ukexec.Config) {
+		c.WarnDeprecated = func(_ context.Context, name, canonical string) {
+			deprecated = append(deprecated, name+"→"+canonical)
+		}
+	})
+
+	mux := newMuxExec(t, exec, warn)
+	assert.NilError(t, mux.RegisterAlias("cp", "copy"))
+	assert.NilError(t, mux.RegisterAlias("dp", "deploy"))
+	assert.NilError(t, mux.RegisterDeprecated("duplicate", "copy"))
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual, deprecated = ukcore.Input{}, nil
+		err := mux.Execute(context.Background(), st.values)
+
+		return st.name, itest.CmpSequence(
+			cmp.Nil(err),
+			cmp.DeepEqual(actual.Target, st.target),
+			cmp.DeepEqual(deprecated, st.deprecated),
+		)
+	}
+
+	t.Run("execute", func(t *testing.T) {
+		itest.Runner[subtest](runner).Run(t,
+			subtest{"canonical", []string{"prog", "copy"}, []string{"copy"}, nil},
+			subtest{"alias", []string{"prog", "cp"}, []string{"copy"}, nil},
+			subtest{"nested alias", []string{"prog", "dp", "now"}, []string{"deploy", "now"}, nil},
+			subtest{"deprecated", []string{"prog", "duplicate", "a"}, []string{"copy"}, []string{"duplicate→copy"}},
+		)
+	})
+
+	t.Run("meta", func(t *testing.T) {
+		meta, err := mux.Meta("cp")
+		assert.NilError(t, err)
+		assert.Check(t, cmp.DeepEqual(meta.Aliases, []string{"cp"}))
+	})
+
+	t.Run("suggestion", func(t *testing.T) {
+		err := mux.Execute(context.Background(), []string{"prog", "duplicat"})
+		assert.Check(t, cmp.Error(err, "unknown command 'duplicat'"))
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		assert.NilError(t, mux.RegisterAlias("cp", "copy"))
+		assert.Check(t, cmp.ErrorIs(mux.RegisterAlias("cp", "deploy"), ukexec.ErrAliasExists))
+		assert.Check(t, cmp.ErrorIs(mux.RegisterAlias("deploy", "copy"), ukexec.ErrAliasExists))
+		assert.Check(t, cmp.ErrorIs(mux.RegisterAlias("root"), ukexec.ErrAliasRoot))
+	})
+}
+
+func TestExecuteHidden(t *testing.T) {
+	type paramsDebug struct {
+		Trace bool `ukflag:"trace" ukhide:""`
+	}
+
+	var actual ukcore.Input
+	exec := func(_ context.Context, in ukcore.Input) error { actual = in; return nil }
+
+	mux := newMuxExec(t, exec)
+
+	specDebug, err := ukspec.ParametersFor[paramsDebug]()
+	assert.NilError(t, err)
+
+	assert.NilError(t, mux.RegisterExec(exec, specDebug, "debug"))
+	assert.NilError(t, mux.RegisterHidden("debug"))
+
+	t.Run("execute", func(t *testing.T) {
+		err := mux.Execute(context.Background(), []string{"prog", "debug", "--trace"})
+		assert.NilError(t, err)
+		assert.Check(t, cmp.DeepEqual(actual.Target, []string{"debug"}))
+		assert.Check(t, cmp.DeepEqual(actual.Flags, []ukcore.Flag{{Name: "trace", Value: "true"}}))
+	})
+
+	t.Run("meta", func(t *testing.T) {
+		meta, err := mux.Meta("debug")
+		assert.NilError(t, err)
+		assert.Check(t, meta.Hidden)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		completion, err := mux.Complete([]string{"prog", "debug", "--"})
+		assert.NilError(t, err)
+		assert.Check(t, !slices.Contains(completion.FlagNames, "trace"))
+	})
+
+	t.Run("suggestion", func(t *testing.T) {
+		err := mux.Execute(context.Background(), []string{"prog", "debug", "--trcae"})
+		assert.Check(t, cmp.Error(err, "invalid flag '--trcae'"))
+	})
+}
+
+func TestExecuteMeta(t *testing.T) {
+	type paramsHelp struct {
+		Format  string `ukflag:"format"`
+		Verbose string `ukflag:"verbose"`
+	}
+
+	var actual ukcore.Input
+	exec := func(_ context.Context, in ukcore.Input) error { actual = in; return nil }
+	mux := newMuxExec(t, exec)
+
+	specHelp, err := ukspec.ParametersFor[paramsHelp]()
+	assert.NilError(t, err)
+
+	// • Expect› No conflict with the (bool) verbose flag of the root
+	assert.NilError(t, mux.RegisterMeta("copy", "help"))
+	assert.NilError(t, mux.RegisterExec(exec, specHelp, "copy", "help"))
+
+	meta, err := mux.Meta("copy")
+	assert.NilError(t, err)
+
+	children := meta.Children()
+	assert.Check(t, children["help"].Meta)
+	assert.Check(t, !children["help"].Hidden)
+
+	t.Run("meta flags", func(t *testing.T) {
+		err := mux.Execute(context.Background(), []string{"prog", "copy", "help", "--format", "json"})
+		assert.NilError(t, err)
+
+		expected := []ukcore.Flag{{Name: "format", Value: "json"}}
+		assert.Check(t, cmp.DeepEqual(actual.Flags, expected))
+	})
+
+	t.Run("ancestor flags", func(t *testing.T) {
+		// • Expect› Meta flags are unknown to ancestors
+		err := mux.Execute(context.Background(), []string{"prog", "copy", "--format", "json"})
+		assert.Check(t, cmp.ErrorContains(err, "format"))
+	})
+}
+
+// =============================================================================
+// Complete
+// =============================================================================
+
+func TestComplete(t *testing.T) {
+	type subtest struct {
+		name     string
+		values   []string
+		kind     ukexec.CompleteKind
+		target   []string
+		position int
+	}
+
+	mux := newMux(t)
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual, err := mux.Complete(st.values)
+
+		return st.name, itest.CmpSequence(
+			cmp.Nil(err),
+			cmp.Equal(actual.Kind, st.kind),
+			cmp.DeepEqual(actual.Target, st.target),
+			cmp.Equal(actual.Position, st.position),
+		)
+	}
+
+	subtests := []subtest{
+		{"program only", []string{"prog"}, ukexec.CompleteCommand, nil, 0},
+		{"subcommand prefix", []string{"prog", "co"}, ukexec.CompleteCommand, nil, 0},
+		{"nested subcommand", []string{"prog", "deploy", ""}, ukexec.CompleteCommand, []string{"deploy"}, 0},
+		{"flag name", []string{"prog", "copy", "--"}, ukexec.CompleteFlagName, []string{"copy"}, 0},
+		{"flag value", []string{"prog", "copy", "--mode", ""}, ukexec.CompleteFlagValue, []string{"copy"}, 0},
+		{"elided flag", []string{"prog", "copy", "--force", ""}, ukexec.CompleteCommand, []string{"copy"}, 0},
+		{"argument", []string{"prog", "copy", "a", ""}, ukexec.CompleteArgument, []string{"copy"}, 1},
+		{"delimited argument", []string{"prog", "copy", "--", "-"}, ukexec.CompleteArgument, []string{"copy"}, 0},
+		{"unknown flag", []string{"prog", "--lorem", "copy", ""}, ukexec.CompleteCommand, []string{"copy"}, 0},
+		{"malformed flag", []string{"prog", "-lorem", "copy", ""}, ukexec.CompleteCommand, []string{"copy"}, 0},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
+func TestCompleteInterspersed(t *testing.T) {
+	type subtest struct {
+		name     string
+		values   []string
+		kind     ukexec.CompleteKind
+		position int
+	}
+
+	mux := newMux(t, withInterspersed)
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual, err := mux.Complete(st.values)
+
+		return st.name, itest.CmpSequence(
+			cmp.Nil(err),
+			cmp.Equal(actual.Kind, st.kind),
+			cmp.Equal(actual.Position, st.position),
+		)
+	}
+
+	subtests := []subtest{
+		{"flag name", []string{"prog", "copy", "a", "-"}, ukexec.CompleteFlagName, 1},
+		{"flag value", []string{"prog", "copy", "a", "--mode", ""}, ukexec.CompleteFlagValue, 1},
+		{"argument", []string{"prog", "copy", "a", "--force", ""}, ukexec.CompleteArgument, 1},
+		{"delimited argument", []string{"prog", "copy", "a", "--", "-"}, ukexec.CompleteArgument, 1},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
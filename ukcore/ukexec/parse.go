@@ -2,7 +2,12 @@ package ukexec
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/internal/ispec"
+	"github.com/oligarch316/ukase/internal/isuggest"
 	"github.com/oligarch316/ukase/ukcore"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
 )
@@ -41,6 +46,13 @@ func (k kind) String() string {
 type token struct {
 	Kind  kind
 	Value string
+
+	// ❬Flag❭ only
+	// • Short  ⇒ value is a cluster of single rune names, eg. "-xyz"
+	// • Inline ⇒ value assigned via '=', eg. "--xx=…" or "-x=…"
+	Short     bool
+	Inline    string
+	HasInline bool
 }
 
 func (t token) String() string { return fmt.Sprintf("❬%s❭ %s", t.Kind, t.Value) }
@@ -63,21 +75,31 @@ func newToken(str string) token {
 		// ❬4 Delim❭ "--"
 		// • ❬3❭ ⇒ rs[0] == '-'
 		return token{Kind: kindDelim, Value: str}
-	case n == 2:
-		// ❬5 Short Flag❭ "-x"
+	case rs[1] == '-':
+		// ❬5 Long Flag❭ "--xx…" | "--xx…=…"
 		// • ❬3❭ ⇒ rs[0] == '-'
-		// • ❬4❭ ⇒ rs[1] != '-'
-		return token{Kind: kindFlag, Value: string(rs[1])}
-	case n > 3 && rs[1] == '-':
-		// ❬6 Long Flag❭ --xx…
+		// • ❬4❭ ⇒ n > 2
+		return newTokenFlag(str, string(rs[2:]), false)
+	default:
+		// ❬6 Short Flag❭ "-x…" | "-x…=…"
 		// • ❬3❭ ⇒ rs[0] == '-'
-		return token{Kind: kindFlag, Value: string(rs[2:])}
+		// • ❬5❭ ⇒ rs[1] != '-'
+		return newTokenFlag(str, string(rs[1:]), true)
+	}
+}
+
+func newTokenFlag(str, body string, short bool) token {
+	name, inline, hasInline := strings.Cut(body, "=")
+
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		// ❬Invalid❭ "-=…" | "--=…"
+		return token{Kind: kindInvalid, Value: str}
+	case n == 1 && !short:
+		// ❬Invalid❭ "--x" | "--x=…"
+		return token{Kind: kindInvalid, Value: str}
 	default:
-		// ❬7 Invalid❭ --x | -xx…
-		// • ❬1,2,5❭ ⇒ n > 2
-		// • ❬3❭     ⇒ rs[0] == '-'
-		// • ❬6❭     ⇒ str != "--xx…"
-		return token{Kind: kindInvalid}
+		return token{Kind: kindFlag, Value: name, Short: short, Inline: inline, HasInline: hasInline}
 	}
 }
 
@@ -90,25 +112,42 @@ const elidePlaceholder = "true"
 type parser struct {
 	Position int
 	Values   []string
+
+	// Tolerant parsers skip malformed and unknown flags rather than fail, and
+	// record the name of a trailing flag left awaiting its value as pending
+	Tolerant bool
+	Pending  string
+
+	// Set when argument parsing was entered via an explicit ❬Delim❭
+	Delim bool
+
+	// Set once argument parsing has begun, after which ❬Empty❭ values are
+	// arguments rather than filler between flags
+	Arguments bool
+
+	// Deprecated subcommand aliases encountered while parsing the target
+	Deprecated []deprecation
 }
 
+type deprecation struct{ Name, Canonical string }
+
 func newParser(values []string) *parser { return &parser{Values: values} }
 
-func (p *parser) consume() {
-	p.Values = p.Values[1:]
-	p.Position += 1
+func (p *parser) consume(n int) {
+	p.Values = p.Values[n:]
+	p.Position += n
 }
 
-func (p *parser) peek() (string, bool) {
-	if len(p.Values) == 0 {
+func (p *parser) peek(offset int) (string, bool) {
+	if len(p.Values) <= offset {
 		return "", false
 	}
-	return p.Values[0], true
+	return p.Values[offset], true
 }
 
 func (p *parser) ConsumeValue() (val string, exists bool) {
-	if val, exists = p.peek(); exists {
-		p.consume()
+	if val, exists = p.peek(0); exists {
+		p.consume(1)
 	}
 	return
 }
@@ -123,7 +162,7 @@ func (p *parser) ConsumeToken() token {
 func (p *parser) ConsumeFlags(specs map[string]ukspec.Flag) ([]ukcore.Flag, error) {
 	var flags []ukcore.Flag
 
-	for peekVal, exists := p.peek(); exists; peekVal, exists = p.peek() {
+	for peekVal, exists := p.peek(0); exists; peekVal, exists = p.peek(0) {
 		peekToken := newToken(peekVal)
 
 		// ❬Delim❭ or ❬String❭ ⇒ do not consume, return flags
@@ -131,66 +170,169 @@ func (p *parser) ConsumeFlags(specs map[string]ukspec.Flag) ([]ukcore.Flag, erro
 			return flags, nil
 		}
 
+		// ❬Empty❭ and parsing arguments ⇒ do not consume, return flags
+		if peekToken.Kind == kindEmpty && p.Arguments {
+			return flags, nil
+		}
+
 		// ❬Empty❭ ⇒ consume and continue
 		if peekToken.Kind == kindEmpty {
-			p.consume()
+			p.consume(1)
 			continue
 		}
 
 		// ❬Flag❭
 		if peekToken.Kind == kindFlag {
-			flagName := peekToken.Value
-			flagSpec, flagValid := specs[flagName]
-
-			// Invalid flag name ⇒ do not consume, fail
-			if !flagValid {
-				return flags, fmt.Errorf("invalid flag '%s'", flagName)
-			}
-
-			// Consume flag name
-			p.consume()
-
-			// Consume flag value
-			flagVal, err := p.consumeFlagValue(flagName, flagSpec)
-			if err != nil {
+			parsed, size, pending, err := p.parseFlag(specs, peekToken)
+
+			switch {
+			case err != nil && p.Tolerant:
+				// Invalid flag name and tolerant ⇒ consume and continue
+				p.consume(1)
+				continue
+			case err != nil:
+				// Invalid flag name ⇒ do not consume, fail
 				return flags, err
+			case pending != "" && p.Tolerant:
+				// Missing value and tolerant ⇒ consume, record pending and return
+				p.consume(size)
+				p.Pending = pending
+				return append(flags, parsed...), nil
+			case pending != "":
+				// Missing value ⇒ do not consume, fail
+				return flags, ierror.FmtU("%w for flag '%s'", ErrMissingValue, ispec.LabelFlag(pending))
 			}
 
-			// Append and continue
-			flags = append(flags, ukcore.Flag{Name: flagName, Value: flagVal})
+			// Consume flag name(s) and value, append and continue
+			p.consume(size)
+			flags = append(flags, parsed...)
 			continue
 		}
 
-		// TODO:
-		// We've been incorrectly treating this as an internal error.
-		// Malformed flags like '--x' or '-xxx' are still user input errors.
+		// ❬Invalid❭ and tolerant ⇒ consume and continue
+		if p.Tolerant {
+			p.consume(1)
+			continue
+		}
 
-		// Unexpected ⇒ do not consume, fail (internal error)
-		return flags, fmt.Errorf("internal ukase parse error: unexpected token kind %s", peekToken.Kind)
+		// ❬Invalid❭ ⇒ do not consume, fail
+		return flags, ierror.FmtU("%w '%s'", ErrMalformedFlag, peekToken.Value)
 	}
 
 	// ❬EOF❭
 	return flags, nil
 }
 
-func (p *parser) consumeFlagValue(name string, spec ukspec.Flag) (string, error) {
-	peekVal, peekExists := p.peek()
+// Parse the flag(s) described by the given token without consuming anything.
+// Returns the parsed flags, the count of values they span and the name of a
+// final flag whose required value is unavailable, if any.
+func (p *parser) parseFlag(specs map[string]ukspec.Flag, tok token) ([]ukcore.Flag, int, string, error) {
+	names := []string{tok.Value}
+	if tok.Short {
+		names = strings.Split(tok.Value, "")
+	}
+
+	var parsed []ukcore.Flag
+
+	for i, name := range names {
+		spec, ok := specs[name]
+		if !ok {
+			err := ierror.FmtU("%w '%s'", ErrInvalidFlag, ispec.LabelFlag(name))
+			return nil, 0, "", newSuggestError(err, suggestFlags(specs, name))
+		}
+
+		switch rest := strings.Join(names[i+1:], ""); {
+		case rest == "" && tok.HasInline:
+			// Final name with inline value ⇒ "--xx=…" | "-x=…"
+			parsed = append(parsed, ukcore.Flag{Name: name, Value: tok.Inline})
+			return parsed, 1, "", nil
+		case rest == "":
+			// Final name ⇒ value (if any) is the following value
+			value, size, ok := p.peekFlagValue(spec)
+			if !ok {
+				return parsed, size, name, nil
+			}
+
+			parsed = append(parsed, ukcore.Flag{Name: name, Value: value})
+			return parsed, size, "", nil
+		case spec.Elide.Allow:
+			// Non-final elidable name ⇒ "-xy…"
+			parsed = append(parsed, ukcore.Flag{Name: name, Value: elidePlaceholder})
+		default:
+			// Non-final valued name ⇒ remainder is the value "-x…"
+			if tok.HasInline {
+				rest += "=" + tok.Inline
+			}
+
+			parsed = append(parsed, ukcore.Flag{Name: name, Value: rest})
+			return parsed, 1, "", nil
+		}
+	}
+
+	// INTERNAL:
+	// • ❬empty names❭ ⇒ `newTokenFlag` should never produce this
+	return nil, 0, "", ierror.FmtI("flag token '%s' contains no names", tok.Value)
+}
+
+func (p *parser) peekFlagValue(spec ukspec.Flag) (string, int, bool) {
+	peekVal, peekExists := p.peek(1)
 	peekUsable := peekExists && spec.Elide.Consumable(peekVal)
 
-	// Required value is not available
-	// ⇒ do not consume, fail
-	if !spec.Elide.Allow && !peekExists {
-		return "", fmt.Errorf("missing value for flag '%s'", name)
+	switch {
+	case spec.Elide.Allow && peekUsable:
+		// Optional value is available and appropriate
+		return peekVal, 2, true
+	case spec.Elide.Allow:
+		// Optional value is either not available or inappropriate
+		// ⇒ use a placeholder
+		return elidePlaceholder, 1, true
+	case peekExists:
+		// Required value is available
+		return peekVal, 2, true
+	default:
+		// Required value is not available
+		return "", 1, false
+	}
+}
+
+// =============================================================================
+// Suggestions
+// =============================================================================
+
+func suggestFlags(specs map[string]ukspec.Flag, name string) []string {
+	// Single rune names are too short for meaningful suggestions
+	if utf8.RuneCountInString(name) < 2 {
+		return nil
+	}
+
+	var candidates []string
+	for candidate, spec := range specs {
+		if !spec.Hidden && !spec.IsDeprecated(candidate) && utf8.RuneCountInString(candidate) > 1 {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	suggestions := isuggest.Closest(name, candidates)
+	for i, suggestion := range suggestions {
+		suggestions[i] = ispec.LabelFlag(suggestion)
 	}
 
-	// Optional value is either not available or inappropriate
-	// ⇒ do not consume, return a placeholder
-	if spec.Elide.Allow && !peekUsable {
-		return elidePlaceholder, nil
+	return suggestions
+}
+
+func suggestCommands(node *muxNode, name string) []string {
+	var candidates []string
+	for candidate, child := range node.children {
+		if !child.hidden {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	for candidate, alias := range node.aliases {
+		if !alias.Deprecated && !node.children[alias.Canonical].hidden {
+			candidates = append(candidates, candidate)
+		}
 	}
 
-	// Value is available and appropriate
-	// ⇒ consume and return value
-	p.consume()
-	return peekVal, nil
+	return isuggest.Closest(name, candidates)
 }
@@ -1,6 +1,9 @@
 package ukinit
 
-import "github.com/oligarch316/ukase/ukcore/ukspec"
+import (
+	"github.com/oligarch316/ukase/ukcore/ukdec"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+)
 
 var defaultConfig = Config{
 	// TODO
@@ -11,6 +14,9 @@ type Option interface{ UkaseApplyInit(*Config) }
 type Config struct {
 	// TODO: Document
 	Spec []ukspec.Option
+
+	// TODO: Document
+	Decode []ukdec.Option
 }
 
 func newConfig(opts []Option) Config {
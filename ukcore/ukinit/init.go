@@ -7,6 +7,7 @@ import (
 	"slices"
 
 	"github.com/oligarch316/ukase/internal/ireflect"
+	"github.com/oligarch316/ukase/ukcore/ukdec"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
 )
 
@@ -55,6 +56,12 @@ func (rs *RuleSet) Process(spec ukspec.Parameters, v any) error {
 		return err
 	}
 
+	// Tag defaults form the baseline
+	// ⇒ Decode prior to any custom or rule based initialization
+	if err := ukdec.DecodeDefaults(spec, v, rs.config.Decode...); err != nil {
+		return err
+	}
+
 	inlines := slices.Clone(spec.Inlines)
 	slices.SortStableFunc(inlines, rs.orderInline)
 
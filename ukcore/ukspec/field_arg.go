@@ -20,7 +20,11 @@ type Argument struct {
 	FieldName  string
 	FieldIndex []int
 
+	Choices  []string
+	Default  string
 	Position ArgumentPosition
+	Required bool
+	Valid    Constraints
 }
 
 func (a Argument) String() string { return fmt.Sprintf("%s (%s)", a.FieldName, a.Position) }
@@ -37,12 +41,28 @@ func loadArgument(s *state, sField reflect.StructField, tag []byte, index int) e
 		FieldType:  sField.Type,
 		FieldName:  sField.Name,
 		FieldIndex: append(s.Scope.FieldIndex, index),
+		Choices:    loadChoices(sField.Type),
 	}
 
 	if err := argument.Position.UnmarshalText(tag); err != nil {
 		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
 	}
 
+	required, err := loadRequired(sField)
+	if err != nil {
+		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+	}
+
+	argument.Required = required
+	argument.Default = loadDefault(sField)
+
+	valid, err := loadConstraints(sField)
+	if err != nil {
+		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+	}
+
+	argument.Valid = valid
+
 	return s.InsertArgument(argument)
 }
 
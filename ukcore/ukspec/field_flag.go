@@ -1,12 +1,16 @@
 package ukspec
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/internal/ispec"
 )
 
 // =============================================================================
@@ -18,12 +22,29 @@ type Flag struct {
 	FieldName  string
 	FieldIndex []int
 
-	Elide FlagElide
-	Names FlagNames
+	Choices    []string
+	Default    string
+	Deprecated FlagNames
+	Elide      FlagElide
+	Env        FlagEnv
+	Hidden     bool
+	Names      FlagNames
+	Repeat     FlagRepeat
+	Required   bool
+	Split      FlagSplit
+	Valid      Constraints
 }
 
 func (f Flag) String() string { return fmt.Sprintf("%s (%s)", f.FieldName, f.Names) }
 
+// IsDeprecated reports whether name is a deprecated spelling of the flag
+func (f Flag) IsDeprecated(name string) bool { return slices.Contains(f.Deprecated, name) }
+
+// Current lists the flag names excluding deprecated spellings
+func (f Flag) Current() FlagNames {
+	return slices.DeleteFunc(slices.Clone(f.Names), f.IsDeprecated)
+}
+
 func loadFlag(s *state, sField reflect.StructField, tag []byte, index int) error {
 	s.Config.Log.Debug("loading flag field", "type", sField.Type, "name", sField.Name)
 
@@ -36,6 +57,7 @@ func loadFlag(s *state, sField reflect.StructField, tag []byte, index int) error
 		FieldType:  sField.Type,
 		FieldName:  sField.Name,
 		FieldIndex: append(s.Scope.FieldIndex, index),
+		Choices:    loadChoices(sField.Type),
 		Elide:      newFlagElide(s.Config, sField),
 	}
 
@@ -43,10 +65,85 @@ func loadFlag(s *state, sField reflect.StructField, tag []byte, index int) error
 		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
 	}
 
+	names, deprecated, err := flag.Names.splitDeprecated()
+	if err != nil {
+		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+	}
+
+	flag.Names, flag.Deprecated = names, deprecated
+
+	if envTag, ok := sField.Tag.Lookup(ispec.TagKeyEnv); ok {
+		if err := flag.Env.UnmarshalText([]byte(envTag)); err != nil {
+			return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+		}
+
+		if flag.Env == "" {
+			// Empty tag ⇒ derive from the longest current (unprefixed) flag name
+			name := slices.MaxFunc(flag.Current(), func(a, b string) int {
+				return utf8.RuneCountInString(a) - utf8.RuneCountInString(b)
+			})
+
+			flag.Env = newFlagEnv(name)
+		}
+
+		flag.Env = newFlagEnv(s.Scope.Prefix.String()) + flag.Env
+	}
+
+	required, err := loadRequired(sField)
+	if err != nil {
+		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+	}
+
+	flag.Required = required
+
+	hidden, err := loadHidden(sField)
+	if err != nil {
+		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+	}
+
+	flag.Hidden = hidden
+	flag.Default = loadDefault(sField)
+
+	valid, err := loadConstraints(sField)
+	if err != nil {
+		return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+	}
+
+	flag.Valid = valid
+
+	if splitTag, ok := sField.Tag.Lookup(ispec.TagKeySplit); ok {
+		if err := flag.Split.UnmarshalText([]byte(splitTag)); err != nil {
+			return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+		}
+
+		if flag.Split == "" {
+			// Empty tag ⇒ split on commas
+			flag.Split = FlagSplitDefault
+		}
+
+		if err := flag.Split.validateType(sField.Type); err != nil {
+			return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+		}
+	}
+
+	if repeatTag, ok := sField.Tag.Lookup(ispec.TagKeyRepeat); ok {
+		if err := flag.Repeat.UnmarshalText([]byte(repeatTag)); err != nil {
+			return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+		}
+
+		if err := flag.Repeat.validateType(sField.Type, flag.Split); err != nil {
+			return InvalidFieldError{Trail: s.Scope.Trail, Field: sField, err: err}
+		}
+	}
+
 	for i, name := range flag.Names {
 		flag.Names[i] = s.Scope.Prefix.String() + name
 	}
 
+	for i, name := range flag.Deprecated {
+		flag.Deprecated[i] = s.Scope.Prefix.String() + name
+	}
+
 	return s.InsertFlag(flag)
 }
 
@@ -86,6 +183,179 @@ func newFlagElide(config Config, sField reflect.StructField) FlagElide {
 	return FlagElide{Allow: false, Consumable: config.ElideConsumable}
 }
 
+// =============================================================================
+// FlagRepeat
+// =============================================================================
+
+// Policy for a flag given more than once, declared via the `ukrepeat` tag
+type FlagRepeat int
+
+const (
+	// Slices accumulate values, all other types keep the last value
+	FlagRepeatDefault FlagRepeat = iota
+
+	// Append each value to the existing (slice) value
+	FlagRepeatAccumulate
+
+	// Keep only the last value, discarding any existing value
+	FlagRepeatLast
+
+	// Discard any existing (slice) value on the first value, then accumulate
+	FlagRepeatReplace
+
+	// Fail if given more than once
+	FlagRepeatError
+)
+
+var flagRepeatNames = map[FlagRepeat]string{
+	FlagRepeatDefault:    "",
+	FlagRepeatAccumulate: "accumulate",
+	FlagRepeatLast:       "last",
+	FlagRepeatReplace:    "replace",
+	FlagRepeatError:      "error",
+}
+
+func (fr FlagRepeat) String() string {
+	if name, ok := flagRepeatNames[fr]; ok {
+		return name
+	}
+	return fmt.Sprintf("FlagRepeat(%d)", int(fr))
+}
+
+func (fr FlagRepeat) MarshalText() ([]byte, error) {
+	if _, ok := flagRepeatNames[fr]; !ok {
+		return nil, ierror.FmtD("unknown flag repeat policy '%d'", int(fr))
+	}
+	return []byte(fr.String()), nil
+}
+
+func (fr *FlagRepeat) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	for candidate, name := range flagRepeatNames {
+		if name == s {
+			*fr = candidate
+			return nil
+		}
+	}
+
+	return ierror.FmtD("unknown flag repeat policy '%s'", s)
+}
+
+func (fr FlagRepeat) validateType(t reflect.Type, split FlagSplit) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch fr {
+	case FlagRepeatAccumulate, FlagRepeatReplace:
+		if kind := t.Kind(); kind != reflect.Slice && kind != reflect.Map {
+			return ierror.FmtD("flag repeat policy '%s' requires a slice or map type", fr)
+		}
+	case FlagRepeatLast, FlagRepeatError:
+		// Array elements without a split separator are gathered one per flag
+		// ⇒ Filling the array requires repetition
+		textUnmarshaler := reflect.PointerTo(t).Implements(typeTextUnmarshaler)
+		if t.Kind() == reflect.Array && split == "" && !textUnmarshaler {
+			return ierror.FmtD("flag repeat policy '%s' requires a split separator for array types", fr)
+		}
+	}
+
+	return nil
+}
+
+var typeTextUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// =============================================================================
+// FlagSplit
+// =============================================================================
+
+// Separator by which a single array, slice or map value is split into elements,
+// declared via the `uksplit` tag, eg. `uksplit:","` ⇒ "--hosts x,y,z"
+type FlagSplit string
+
+const FlagSplitDefault FlagSplit = ","
+
+func (fs FlagSplit) String() string { return string(fs) }
+
+func (fs FlagSplit) MarshalText() ([]byte, error) {
+	str, err := fs.String(), fs.validate()
+	return []byte(str), err
+}
+
+func (fs *FlagSplit) UnmarshalText(text []byte) error {
+	*fs = FlagSplit(text)
+	return fs.validate()
+}
+
+func (fs FlagSplit) validate() error {
+	// Backslashes and double quotes escape and quote separators in values
+	if strings.ContainsAny(string(fs), `\"`) {
+		return ierror.FmtD("flag split '%s' contains reserved '\\' or '\"' character", fs)
+	}
+
+	return nil
+}
+
+func (fs FlagSplit) validateType(t reflect.Type) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Array, reflect.Slice:
+		return nil
+	case reflect.Map:
+		// Map elements are "key=value" pairs
+		if strings.ContainsRune(string(fs), '=') {
+			return ierror.FmtD("flag split '%s' contains reserved '=' character", fs)
+		}
+		return nil
+	default:
+		return ierror.FmtD("flag split '%s' requires an array, slice or map type", fs)
+	}
+}
+
+// =============================================================================
+// FlagEnv
+// =============================================================================
+
+type FlagEnv string
+
+var flagEnvReplacer = strings.NewReplacer("-", "_", ".", "_")
+
+// Convert a flag name or inline prefix to conventional environment variable
+// form, eg. "db-host" ⇒ "DB_HOST"
+func newFlagEnv(name string) FlagEnv {
+	return FlagEnv(strings.ToUpper(flagEnvReplacer.Replace(name)))
+}
+
+func (fe FlagEnv) String() string { return string(fe) }
+
+func (fe FlagEnv) MarshalText() ([]byte, error) {
+	str, err := fe.String(), fe.validate()
+	return []byte(str), err
+}
+
+func (fe *FlagEnv) UnmarshalText(text []byte) error {
+	*fe = FlagEnv(text)
+	return fe.validate()
+}
+
+func (fe FlagEnv) validate() error {
+	s := string(fe)
+
+	if strings.ContainsFunc(s, unicode.IsSpace) {
+		return ierror.FmtD("flag env '%s' contains whitespace", s)
+	}
+
+	if strings.ContainsRune(s, '=') {
+		return ierror.FmtD("flag env '%s' contains reserved '=' character", s)
+	}
+
+	return nil
+}
+
 // =============================================================================
 // FlagNames
 // =============================================================================
@@ -104,6 +374,30 @@ func (fn *FlagNames) UnmarshalText(text []byte) error {
 	return fn.validate()
 }
 
+// Names suffixed with a '!' marker are deprecated spellings, eg.
+// `ukflag:"new old!"`, which remain accepted but are listed after all current
+// names and (marker removed) in the returned deprecated subset
+func (fn FlagNames) splitDeprecated() (names, deprecated FlagNames, err error) {
+	for _, name := range fn {
+		if trimmed, ok := strings.CutSuffix(name, "!"); ok {
+			if trimmed == "" {
+				return nil, nil, ierror.FmtD("flag name '%s' is empty", name)
+			}
+
+			deprecated = append(deprecated, trimmed)
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, nil, ierror.NewD("flag names all deprecated")
+	}
+
+	return append(names, deprecated...), deprecated, nil
+}
+
 func (fn FlagNames) validate() error {
 	if len(fn) == 0 {
 		return ierror.NewD("flag names empty")
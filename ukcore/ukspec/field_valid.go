@@ -0,0 +1,152 @@
+package ukspec
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/internal/ispec"
+)
+
+// =============================================================================
+// Constraints
+// =============================================================================
+
+// Field constraints declared via the `ukvalid` tag as a comma separated list
+// of "key=value" items, eg. `ukvalid:"min=1,max=10"`.
+//
+// • min=N, max=N ⇒ bound numeric values, or the length of strings, slices,
+// arrays and maps
+// • oneof=a|b|… ⇒ restrict values (or elements) to the given choices
+// • regex=… ⇒ match values (or elements) against a regular expression,
+// which consumes the remainder of the tag and so must come last
+type Constraints []Constraint
+
+type Constraint struct {
+	Kind  ConstraintKind
+	Value string
+
+	// Parsed form of the value, per kind
+	Bound   float64
+	Choices []string
+	Pattern *regexp.Regexp
+}
+
+type ConstraintKind string
+
+const (
+	ConstraintMin   ConstraintKind = "min"
+	ConstraintMax   ConstraintKind = "max"
+	ConstraintOneOf ConstraintKind = "oneof"
+	ConstraintRegex ConstraintKind = "regex"
+)
+
+func (c Constraint) String() string { return string(c.Kind) + "=" + c.Value }
+
+func (cs Constraints) String() string {
+	items := make([]string, len(cs))
+	for i, c := range cs {
+		items[i] = c.String()
+	}
+	return strings.Join(items, ",")
+}
+
+func (cs Constraints) MarshalText() ([]byte, error) { return []byte(cs.String()), nil }
+
+func (cs *Constraints) UnmarshalText(text []byte) error {
+	var list Constraints
+
+	for rest := strings.TrimSpace(string(text)); rest != ""; {
+		var item string
+
+		if strings.HasPrefix(rest, string(ConstraintRegex)+"=") {
+			item, rest = rest, ""
+		} else {
+			item, rest, _ = strings.Cut(rest, ",")
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return ierror.FmtD("constraint '%s' is missing a value", item)
+		}
+
+		c, err := newConstraint(ConstraintKind(key), value)
+		if err != nil {
+			return err
+		}
+
+		list = append(list, c)
+	}
+
+	*cs = list
+	return nil
+}
+
+func newConstraint(kind ConstraintKind, value string) (Constraint, error) {
+	c := Constraint{Kind: kind, Value: value}
+
+	switch kind {
+	case ConstraintMin, ConstraintMax:
+		bound, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c, ierror.FmtD("constraint '%s' bound is not a number", c)
+		}
+		c.Bound = bound
+	case ConstraintOneOf:
+		c.Choices = strings.Split(value, "|")
+	case ConstraintRegex:
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return c, ierror.FmtD("constraint '%s': %w", c, err)
+		}
+		c.Pattern = pattern
+	default:
+		return c, ierror.FmtD("unknown constraint '%s'", kind)
+	}
+
+	return c, nil
+}
+
+func (cs Constraints) validateType(t reflect.Type) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	for _, c := range cs {
+		if c.Kind != ConstraintMin && c.Kind != ConstraintMax {
+			continue
+		}
+
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		case reflect.Float32, reflect.Float64:
+		case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		default:
+			return ierror.FmtD("constraint '%s' requires a numeric, string, slice, array or map type", c)
+		}
+	}
+
+	return nil
+}
+
+func loadConstraints(sField reflect.StructField) (Constraints, error) {
+	tag, ok := sField.Tag.Lookup(ispec.TagKeyValid)
+	if !ok {
+		return nil, nil
+	}
+
+	var constraints Constraints
+
+	if err := constraints.UnmarshalText([]byte(tag)); err != nil {
+		return nil, err
+	}
+
+	if err := constraints.validateType(sField.Type); err != nil {
+		return nil, err
+	}
+
+	return constraints, nil
+}
@@ -0,0 +1,99 @@
+package ukspec
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+)
+
+// =============================================================================
+// FlagGroup
+// =============================================================================
+
+type FlagGroupKind int
+
+const (
+	// At most one member flag may be present
+	FlagGroupExclusive FlagGroupKind = iota + 1
+
+	// Either all member flags or none may be present
+	FlagGroupTogether
+)
+
+func (k FlagGroupKind) String() string {
+	switch k {
+	case FlagGroupExclusive:
+		return "exclusive"
+	case FlagGroupTogether:
+		return "together"
+	default:
+		return fmt.Sprintf("FlagGroupKind(%d)", int(k))
+	}
+}
+
+// Flag groups are declared by an optional `UkaseGroups() []ukspec.FlagGroup`
+// method on the parameters type or any inline type. Member names refer to
+// flags by any of their (unprefixed) names.
+type FlagGroup struct {
+	Kind  FlagGroupKind
+	Names FlagNames
+}
+
+func Exclusive(names ...string) FlagGroup {
+	return FlagGroup{Kind: FlagGroupExclusive, Names: names}
+}
+
+func Together(names ...string) FlagGroup {
+	return FlagGroup{Kind: FlagGroupTogether, Names: names}
+}
+
+func (g FlagGroup) String() string { return fmt.Sprintf("%s (%s)", g.Kind, g.Names) }
+
+func loadGroups(s *state) {
+	type grouper interface{ UkaseGroups() []FlagGroup }
+
+	x, ok := reflect.New(s.Scope.FieldType).Interface().(grouper)
+	if !ok {
+		return
+	}
+
+	for _, group := range x.UkaseGroups() {
+		names := make(FlagNames, len(group.Names))
+		for i, name := range group.Names {
+			names[i] = s.Scope.Prefix.String() + name
+		}
+
+		s.groupList = append(s.groupList, FlagGroup{Kind: group.Kind, Names: names})
+	}
+}
+
+func (g FlagGroup) validate(flagMap map[string]Flag) error {
+	switch g.Kind {
+	case FlagGroupExclusive, FlagGroupTogether:
+	default:
+		return ierror.FmtD("flag group '%s' has unknown kind", g)
+	}
+
+	if len(g.Names) < 2 {
+		return ierror.FmtD("flag group '%s' has fewer than 2 members", g)
+	}
+
+	var members [][]int
+
+	for _, name := range g.Names {
+		flag, ok := flagMap[name]
+		if !ok {
+			return ierror.FmtD("flag group '%s' references unknown flag name '%s'", g, name)
+		}
+
+		if slices.ContainsFunc(members, func(index []int) bool { return slices.Equal(index, flag.FieldIndex) }) {
+			return ierror.FmtD("flag group '%s' references flag '%s' more than once", g, flag)
+		}
+
+		members = append(members, flag.FieldIndex)
+	}
+
+	return nil
+}
@@ -3,6 +3,8 @@ package ukspec
 import (
 	"reflect"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/oligarch316/ukase/internal/ierror"
 	"github.com/oligarch316/ukase/internal/ispec"
@@ -17,6 +19,7 @@ type Parameters struct {
 
 	Arguments []Argument
 	Flags     []Flag
+	Groups    []FlagGroup
 	Inlines   []Inline
 
 	flagNames map[string]Flag
@@ -53,10 +56,19 @@ func NewParameters(t reflect.Type, opts ...Option) (Parameters, error) {
 		}
 	}
 
+	// Group members may reference flags of nested inlines
+	// ⇒ Validate only once all scopes are loaded
+	for _, group := range s.groupList {
+		if err := group.validate(s.flagMap); err != nil {
+			return Parameters{}, InvalidParametersError{Type: t, err: err}
+		}
+	}
+
 	params := Parameters{
 		Type:      paramsType,
 		Arguments: s.argumentList,
 		Flags:     s.flagList,
+		Groups:    s.groupList,
 		Inlines:   s.inlineList,
 		flagNames: s.flagMap,
 	}
@@ -100,6 +112,7 @@ func loadStruct(s *state) error {
 		}
 	}
 
+	loadGroups(s)
 	return nil
 }
 
@@ -124,3 +137,57 @@ func loadField(s *state, index int) error {
 	// Untagged ⇒ ignore
 	return nil
 }
+
+// Choices are provided by an optional `UkaseChoices() []string` method on the
+// field type, or else on its (pointer, slice or array) element type
+func loadChoices(t reflect.Type) []string {
+	type chooser interface{ UkaseChoices() []string }
+
+	for t != nil {
+		if x, ok := reflect.New(t).Interface().(chooser); ok {
+			return x.UkaseChoices()
+		}
+
+		switch t.Kind() {
+		case reflect.Array, reflect.Pointer, reflect.Slice:
+			t = t.Elem()
+		default:
+			t = nil
+		}
+	}
+
+	return nil
+}
+
+func loadRequired(sField reflect.StructField) (bool, error) {
+	return loadBoolTag(sField, ispec.TagKeyRequired, "required")
+}
+
+func loadHidden(sField reflect.StructField) (bool, error) {
+	return loadBoolTag(sField, ispec.TagKeyHidden, "hidden")
+}
+
+// Defaults are declared via the `ukdefault` tag as text, decoded (and hence
+// validated) only when initializing parameters, eg. `ukdefault:"8080"`
+func loadDefault(sField reflect.StructField) string {
+	return sField.Tag.Get(ispec.TagKeyDefault)
+}
+
+func loadBoolTag(sField reflect.StructField, key, desc string) (bool, error) {
+	tag, ok := sField.Tag.Lookup(key)
+	if !ok {
+		return false, nil
+	}
+
+	// Empty tag ⇒ true
+	if tag = strings.TrimSpace(tag); tag == "" {
+		return true, nil
+	}
+
+	val, err := strconv.ParseBool(tag)
+	if err != nil {
+		return false, ierror.FmtD("%s tag '%s' exhibits invalid bool syntax", desc, tag)
+	}
+
+	return val, nil
+}
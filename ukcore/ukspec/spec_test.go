@@ -83,6 +83,114 @@ func TestLoadParametersError(t *testing.T) {
 		}
 		t.Run("invalid inline tag", runParamsError[Params, IFE])
 	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukenv:"LOREM IPSUM"`
+		}
+		t.Run("invalid env tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukreq:"ipsum"`
+		}
+		t.Run("invalid required flag tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			ArgA string `ukarg:"0" ukreq:"ipsum"`
+		}
+		t.Run("invalid required argument tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukhide:"ipsum"`
+		}
+		t.Run("invalid hidden flag tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem! ipsum!"`
+		}
+		t.Run("all deprecated flag names", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem !"`
+		}
+		t.Run("empty deprecated flag name", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA []string `ukflag:"lorem" ukrepeat:"ipsum"`
+		}
+		t.Run("invalid repeat tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukrepeat:"replace"`
+		}
+		t.Run("non-slice replace repeat", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA [2]string `ukflag:"lorem" ukrepeat:"last"`
+		}
+		t.Run("unsplit array last repeat", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA *[2]string `ukflag:"lorem" ukrepeat:"error"`
+		}
+		t.Run("unsplit array error repeat", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA []string `ukflag:"lorem" uksplit:"\\"`
+		}
+		t.Run("reserved split tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA map[string]string `ukflag:"lorem" uksplit:"="`
+		}
+		t.Run("reserved map split tag", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" uksplit:","`
+		}
+		t.Run("non-container split", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukvalid:"ipsum=1"`
+		}
+		t.Run("unknown valid constraint", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukvalid:"min"`
+		}
+		t.Run("missing valid constraint value", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukvalid:"min=ipsum"`
+		}
+		t.Run("invalid valid bound", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			FlagA bool `ukflag:"lorem" ukvalid:"max=1"`
+		}
+		t.Run("unsupported valid bound type", runParamsError[Params, IFE])
+	}
+	{
+		type Params struct {
+			ArgA string `ukarg:"0" ukvalid:"regex=("`
+		}
+		t.Run("invalid valid regex", runParamsError[Params, IFE])
+	}
 
 	// --- Argument positions must not conflict
 	{
@@ -121,6 +229,13 @@ func TestLoadParametersError(t *testing.T) {
 		}
 		t.Run("conflicting flag names across fields", runParamsError[Params, CEF])
 	}
+	{
+		type Params struct {
+			FlagA string `ukflag:"lorem" ukenv:"DOLOR"`
+			FlagB string `ukflag:"ipsum" ukenv:"DOLOR"`
+		}
+		t.Run("conflicting flag envs across fields", runParamsError[Params, CEF])
+	}
 
 	// --- Inline graph must not contain cycles
 	// TODO:
@@ -247,6 +362,258 @@ func TestLoadParametersSuccess(t *testing.T) {
 	}
 }
 
+func TestLoadParametersEnv(t *testing.T) {
+	type Inner struct {
+		FlagA string `ukflag:"a-a" ukenv:""`
+		FlagB string `ukflag:"b" ukenv:"BETA"`
+	}
+
+	type Params struct {
+		FlagLorem string `ukflag:"lorem" ukenv:""`
+		FlagIpsum string `ukflag:"ipsum" ukenv:"IPSUM_OVERRIDE"`
+		FlagDolor string `ukflag:"dolor"`
+		FlagOut   string `ukflag:"o out output-file!" ukenv:""`
+
+		Inner Inner `ukinline:"inner."`
+	}
+
+	expected := map[string]ukspec.FlagEnv{
+		"lorem":     "LOREM",
+		"ipsum":     "IPSUM_OVERRIDE",
+		"dolor":     "",
+		"o":         "OUT",
+		"inner.a-a": "INNER_A_A",
+		"inner.b":   "INNER_BETA",
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	for name, env := range expected {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.Equal(flag.Env, env), "unexpected env for flag name '%s'", name)
+	}
+}
+
+func TestLoadParametersRequired(t *testing.T) {
+	type Params struct {
+		ArgA  string   `ukarg:"0" ukreq:""`
+		ArgB  []string `ukarg:"1:"`
+		FlagA string   `ukflag:"lorem" ukreq:""`
+		FlagB string   `ukflag:"ipsum" ukreq:"false"`
+		FlagC string   `ukflag:"dolor" ukreq:"true"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	for position, expected := range []bool{true, false} {
+		arg, ok := params.LookupArgument(position)
+		assert.Check(t, ok, "missing argument position '%d'", position)
+		assert.Check(t, cmp.Equal(arg.Required, expected), "unexpected required for argument position '%d'", position)
+	}
+
+	for name, expected := range map[string]bool{"lorem": true, "ipsum": false, "dolor": true} {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.Equal(flag.Required, expected), "unexpected required for flag name '%s'", name)
+	}
+}
+
+func TestLoadParametersHidden(t *testing.T) {
+	type Params struct {
+		FlagA string `ukflag:"lorem" ukhide:""`
+		FlagB string `ukflag:"ipsum" ukhide:"false"`
+		FlagC string `ukflag:"dolor"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	for name, expected := range map[string]bool{"lorem": true, "ipsum": false, "dolor": false} {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.Equal(flag.Hidden, expected), "unexpected hidden for flag name '%s'", name)
+	}
+}
+
+func TestLoadParametersDefault(t *testing.T) {
+	type Params struct {
+		ArgA  string `ukarg:"0" ukdefault:"lorem"`
+		FlagA int    `ukflag:"ipsum" ukdefault:"42"`
+		FlagB string `ukflag:"dolor"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	arg, ok := params.LookupArgument(0)
+	assert.Check(t, ok, "missing argument position '0'")
+	assert.Check(t, cmp.Equal(arg.Default, "lorem"))
+
+	for name, expected := range map[string]string{"ipsum": "42", "dolor": ""} {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.Equal(flag.Default, expected), "unexpected default for flag name '%s'", name)
+	}
+}
+
+func TestLoadParametersSplit(t *testing.T) {
+	type Params struct {
+		FlagA []string          `ukflag:"lorem" uksplit:""`
+		FlagB *[]int            `ukflag:"ipsum" uksplit:";"`
+		FlagC map[string]string `ukflag:"dolor" uksplit:" "`
+		FlagD []string          `ukflag:"sit"`
+		FlagE [2]int            `ukflag:"amet" uksplit:""`
+		FlagF [2]int            `ukflag:"consectetur" uksplit:"" ukrepeat:"last"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	expected := map[string]ukspec.FlagSplit{"lorem": ",", "ipsum": ";", "dolor": " ", "sit": "", "amet": ",", "consectetur": ","}
+
+	for name, split := range expected {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.Equal(flag.Split, split), "unexpected split for flag name '%s'", name)
+	}
+}
+
+func TestLoadParametersValid(t *testing.T) {
+	type Params struct {
+		FlagA int      `ukflag:"lorem" ukvalid:"min=1,max=10"`
+		FlagB []string `ukflag:"ipsum" ukvalid:"oneof=a|b,regex=^[a,b]$"`
+		FlagC string   `ukflag:"dolor"`
+		ArgA  *string  `ukarg:"0" ukvalid:"max=3"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	expected := map[string]string{"lorem": "min=1,max=10", "ipsum": "oneof=a|b,regex=^[a,b]$", "dolor": ""}
+
+	for name, valid := range expected {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.Equal(flag.Valid.String(), valid), "unexpected constraints for flag name '%s'", name)
+	}
+
+	arg, ok := params.LookupArgument(0)
+	assert.Check(t, ok, "missing argument position '0'")
+	assert.Check(t, cmp.Equal(arg.Valid.String(), "max=3"))
+}
+
+func TestLoadParametersDeprecated(t *testing.T) {
+	type Inner struct {
+		FlagA string `ukflag:"lorem ipsum!"`
+	}
+
+	type Params struct {
+		Inner Inner  `ukinline:"inner-"`
+		FlagB string `ukflag:"d old-dolor! dolor"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	for name, expected := range map[string]ukspec.FlagNames{"inner-lorem": {"inner-lorem"}, "dolor": {"d", "dolor"}} {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.DeepEqual(flag.Current(), expected), "unexpected current names for flag name '%s'", name)
+	}
+
+	for name, current := range map[string]string{"inner-ipsum": "inner-lorem", "old-dolor": "dolor"} {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, flag.IsDeprecated(name), "expected deprecated flag name '%s'", name)
+		assert.Check(t, !flag.IsDeprecated(current), "unexpected deprecated flag name '%s'", current)
+	}
+}
+
+type groupInner struct {
+	Cert string `ukflag:"cert"`
+	Key  string `ukflag:"key"`
+}
+
+func (groupInner) UkaseGroups() []ukspec.FlagGroup {
+	return []ukspec.FlagGroup{ukspec.Together("cert", "key")}
+}
+
+type groupParams struct {
+	Inner  groupInner `ukinline:"tls-"`
+	Tag    string     `ukflag:"t tag"`
+	Digest string     `ukflag:"digest"`
+}
+
+func (groupParams) UkaseGroups() []ukspec.FlagGroup {
+	return []ukspec.FlagGroup{ukspec.Exclusive("t", "digest")}
+}
+
+type groupInvalid struct {
+	Lorem string `ukflag:"l lorem"`
+	Ipsum string `ukflag:"ipsum"`
+}
+
+func (groupInvalid) UkaseGroups() []ukspec.FlagGroup {
+	return []ukspec.FlagGroup{{Kind: ukspec.FlagGroupExclusive, Names: groupInvalidNames}}
+}
+
+var groupInvalidNames ukspec.FlagNames
+
+func TestLoadParametersGroups(t *testing.T) {
+	params, err := ukspec.ParametersFor[groupParams]()
+	assert.NilError(t, err)
+
+	expected := []ukspec.FlagGroup{
+		ukspec.Exclusive("t", "digest"),
+		ukspec.Together("tls-cert", "tls-key"),
+	}
+
+	assert.Check(t, cmp.DeepEqual(params.Groups, expected))
+
+	runError := func(names ...string) func(*testing.T) {
+		return func(t *testing.T) {
+			groupInvalidNames = names
+			_, err := ukspec.ParametersFor[groupInvalid]()
+			assert.Check(t, itest.CmpErrorAsD[ukspec.InvalidParametersError](err))
+		}
+	}
+
+	t.Run("unknown member", runError("lorem", "dolor"))
+	t.Run("duplicate member", runError("l", "lorem"))
+	t.Run("single member", runError("lorem"))
+}
+
+type choiceFormat string
+
+func (choiceFormat) UkaseChoices() []string { return []string{"json", "text"} }
+
+func TestLoadParametersChoices(t *testing.T) {
+	type Params struct {
+		ArgA  []choiceFormat `ukarg:":"`
+		FlagA choiceFormat   `ukflag:"lorem"`
+		FlagB *choiceFormat  `ukflag:"ipsum"`
+		FlagC string         `ukflag:"dolor"`
+	}
+
+	params, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	expected := []string{"json", "text"}
+
+	arg, ok := params.LookupArgument(0)
+	assert.Check(t, ok, "missing argument position '0'")
+	assert.Check(t, cmp.DeepEqual(arg.Choices, expected))
+
+	for name, expected := range map[string][]string{"lorem": expected, "ipsum": expected, "dolor": nil} {
+		flag, ok := params.LookupFlag(name)
+		assert.Check(t, ok, "missing flag name '%s'", name)
+		assert.Check(t, cmp.DeepEqual(flag.Choices, expected), "unexpected choices for flag name '%s'", name)
+	}
+}
+
 // =============================================================================
 // Unmarshal Tag
 // =============================================================================
@@ -304,6 +671,20 @@ func TestUnmarshalTagError(t *testing.T) {
 		itest.Run(t, runner, subtests...)
 	})
 
+	t.Run("flag env", func(t *testing.T) {
+		subtests := []subtest{
+			{"whitespace present", "LOREM IPSUM", itest.CmpErrorIsD},
+			{"equals present", "LOREM=IPSUM", itest.CmpErrorIsD},
+		}
+
+		runner := func(st subtest) (string, cmp.Comparison) {
+			_, err := loadTag[ukspec.FlagEnv](st.input)
+			return st.name, st.compare(err)
+		}
+
+		itest.Run(t, runner, subtests...)
+	})
+
 	t.Run("inline prefix", func(t *testing.T) {
 		subtests := []subtest{
 			{"hyphen prefix", "-lorem", itest.CmpErrorIsD},
@@ -21,8 +21,10 @@ type state struct {
 
 	argumentList []Argument
 	flagList     []Flag
+	groupList    []FlagGroup
 	inlineList   []Inline
 
+	envMap     map[FlagEnv]Flag
 	flagMap    map[string]Flag
 	scopeQueue []scope
 }
@@ -32,6 +34,7 @@ func newState(config Config, t reflect.Type) *state {
 
 	return &state{
 		Config:     config,
+		envMap:     make(map[FlagEnv]Flag),
 		flagMap:    make(map[string]Flag),
 		scopeQueue: []scope{seed},
 	}
@@ -90,6 +93,15 @@ func (s *state) InsertFlag(update Flag) error {
 		s.flagMap[name] = update
 	}
 
+	if update.Env != "" {
+		if original, exists := s.envMap[update.Env]; exists {
+			err := fmt.Errorf("duplicated flag env '%s'", update.Env)
+			return ConflictError[Flag]{Trail: s.Scope.Trail, Original: original, Update: update, err: err}
+		}
+
+		s.envMap[update.Env] = update
+	}
+
 	s.flagList = append(s.flagList, update)
 	return nil
 }
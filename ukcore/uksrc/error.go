@@ -0,0 +1,41 @@
+package uksrc
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+)
+
+var (
+	ErrInvalidFile  = errors.New("invalid file error")
+	ErrInvalidValue = errors.New("invalid value error")
+)
+
+type InvalidFileError struct {
+	Path string
+	err  error
+}
+
+type InvalidValueError struct {
+	Key   []string
+	Value any
+	err   error
+}
+
+var errIsTagged = ierror.IsTaggedFunc(ierror.ErrSrc)
+
+func (e InvalidFileError) Is(t error) bool  { return errIsTagged(t, ErrInvalidFile) }
+func (e InvalidValueError) Is(t error) bool { return errIsTagged(t, ErrInvalidValue) }
+
+func (e InvalidFileError) Unwrap() error  { return e.err }
+func (e InvalidValueError) Unwrap() error { return e.err }
+
+func (e InvalidFileError) Error() string {
+	return fmt.Sprintf("invalid source file '%s': %s", e.Path, e.err)
+}
+
+func (e InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid source value '%s': %s", strings.Join(e.Key, "."), e.err)
+}
@@ -0,0 +1,231 @@
+package uksrc
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+)
+
+// =============================================================================
+// Source
+// =============================================================================
+
+// Source produces flag values for the given parameters, keyed by the same
+// names declared in `ukspec.FlagNames`.
+type Source interface {
+	UkaseSource(ukspec.Parameters) ([]ukcore.Flag, error)
+}
+
+// =============================================================================
+// Map
+// › Handles nested `map[string]any` data, as produced by typical JSON, TOML
+// › and YAML unmarshal routines
+// =============================================================================
+
+type Map map[string]any
+
+func (m Map) UkaseSource(spec ukspec.Parameters) ([]ukcore.Flag, error) {
+	var flags []ukcore.Flag
+
+	for _, flagSpec := range spec.Flags {
+		section, prefix := m.section(spec, flagSpec)
+
+		for _, name := range flagSpec.Names {
+			// Nested ⇒ { "db": { "host": … } }
+			key := append(slices.Clone(section), strings.TrimPrefix(name, prefix.String()))
+			val, ok := m.lookup(key)
+
+			if !ok {
+				// Flat ⇒ { "db-host": … }
+				key = []string{name}
+				val, ok = m.lookup(key)
+			}
+
+			if !ok {
+				continue
+			}
+
+			values, err := m.encodeField(flagSpec, val)
+			if err != nil {
+				return nil, InvalidValueError{Key: key, Value: val, err: err}
+			}
+
+			for _, value := range values {
+				flags = append(flags, ukcore.Flag{Name: name, Value: value})
+			}
+
+			break
+		}
+	}
+
+	return flags, nil
+}
+
+// Determine the section path and full inline prefix of the given flag.
+// Each inline with a non-empty prefix contributes one section named by that
+// prefix, less any trailing separator characters, eg. "db-" ⇒ "db".
+func (Map) section(spec ukspec.Parameters, flagSpec ukspec.Flag) (section []string, prefix ukspec.InlinePrefix) {
+	for depth := 1; depth < len(flagSpec.FieldIndex); depth++ {
+		match := func(i ukspec.Inline) bool { return slices.Equal(i.FieldIndex, flagSpec.FieldIndex[:depth]) }
+
+		idx := slices.IndexFunc(spec.Inlines, match)
+		if idx == -1 {
+			continue
+		}
+
+		inline := spec.Inlines[idx]
+
+		segment := strings.TrimPrefix(inline.Prefix.String(), prefix.String())
+		if name := strings.TrimRight(segment, "-._"); name != "" {
+			section = append(section, name)
+		}
+
+		prefix = inline.Prefix
+	}
+
+	return
+}
+
+func (m Map) lookup(key []string) (any, bool) {
+	cur := map[string]any(m)
+
+	for _, name := range key[:len(key)-1] {
+		next, ok := cur[name].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		cur = next
+	}
+
+	val, ok := cur[key[len(key)-1]]
+	return val, ok
+}
+
+func (m Map) encodeField(flagSpec ukspec.Flag, val any) ([]string, error) {
+	section, ok := val.(map[string]any)
+	if !ok || !isMapType(flagSpec.FieldType) {
+		return m.encode(val)
+	}
+
+	// Section targeting a map field ⇒ one "key=value" pair per entry
+	keys := make([]string, 0, len(section))
+	for key := range section {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var pairs []string
+
+	for _, key := range keys {
+		values, err := m.encode(section[key])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+
+	return pairs, nil
+}
+
+func (m Map) encode(val any) ([]string, error) {
+	switch valT := val.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{valT}, nil
+	case bool:
+		return []string{strconv.FormatBool(valT)}, nil
+	case float32:
+		return []string{strconv.FormatFloat(float64(valT), 'f', -1, 32)}, nil
+	case float64:
+		return []string{strconv.FormatFloat(valT, 'f', -1, 64)}, nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, json.Number:
+		return []string{fmt.Sprint(valT)}, nil
+	case encoding.TextMarshaler:
+		text, err := valT.MarshalText()
+		if err != nil {
+			return nil, ierror.U(err)
+		}
+
+		return []string{string(text)}, nil
+	case map[string]any:
+		return nil, ierror.NewU("section found where value expected")
+	case []any:
+		var list []string
+
+		for _, item := range valT {
+			if _, nested := item.([]any); nested {
+				return nil, ierror.NewU("nested list values not supported")
+			}
+
+			values, err := m.encode(item)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, values...)
+		}
+
+		return list, nil
+	default:
+		return nil, ierror.FmtU("unsupported value type '%T'", valT)
+	}
+}
+
+func isMapType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map
+}
+
+// =============================================================================
+// File
+// › Handles any format with an unmarshal routine accepting `*map[string]any`
+// › TOML and YAML may be plugged in via their respective libraries, eg.
+//   File{Path: "config.yaml", Unmarshal: yaml.Unmarshal}
+// =============================================================================
+
+type File struct {
+	Path      string
+	Optional  bool
+	Unmarshal func(data []byte, v any) error
+}
+
+func NewFile(path string, unmarshal func([]byte, any) error) File {
+	return File{Path: path, Unmarshal: unmarshal}
+}
+
+func NewFileJSON(path string) File { return NewFile(path, json.Unmarshal) }
+
+func (f File) UkaseSource(spec ukspec.Parameters) ([]ukcore.Flag, error) {
+	data, err := os.ReadFile(f.Path)
+	switch {
+	case f.Optional && errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, InvalidFileError{Path: f.Path, err: ierror.U(err)}
+	}
+
+	var m map[string]any
+	if err := f.Unmarshal(data, &m); err != nil {
+		return nil, InvalidFileError{Path: f.Path, err: ierror.U(err)}
+	}
+
+	return Map(m).UkaseSource(spec)
+}
@@ -0,0 +1,153 @@
+package uksrc_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oligarch316/ukase/internal/itest"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+	"github.com/oligarch316/ukase/ukcore/uksrc"
+	"gotest.tools/v3/assert"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type Inner struct {
+	Host string `ukflag:"host"`
+	Port int    `ukflag:"port p"`
+}
+
+type Params struct {
+	Lorem string   `ukflag:"lorem"`
+	Ipsum []string `ukflag:"ipsum"`
+	Dolor bool     `ukflag:"dolor"`
+
+	Inner Inner `ukinline:"db-"`
+	Flat  Inner `ukinline:""`
+}
+
+func loadSpec(t *testing.T) ukspec.Parameters {
+	spec, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+	return spec
+}
+
+// =============================================================================
+// Tests
+// =============================================================================
+
+func TestMapSource(t *testing.T) {
+	source := uksrc.Map{
+		"lorem": "lorem-val",
+		"ipsum": []any{"a", "b"},
+		"dolor": true,
+		"db": map[string]any{
+			"host": "db-host-val",
+			"p":    float64(42),
+		},
+		"host": "flat-host-val",
+	}
+
+	expected := []ukcore.Flag{
+		{Name: "lorem", Value: "lorem-val"},
+		{Name: "ipsum", Value: "a"},
+		{Name: "ipsum", Value: "b"},
+		{Name: "dolor", Value: "true"},
+		{Name: "db-host", Value: "db-host-val"},
+		{Name: "db-p", Value: "42"},
+		{Name: "host", Value: "flat-host-val"},
+	}
+
+	actual, err := source.UkaseSource(loadSpec(t))
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, actual, expected)
+}
+
+func TestMapSourceFlat(t *testing.T) {
+	source := uksrc.Map{"db-host": "db-host-val"}
+	expected := []ukcore.Flag{{Name: "db-host", Value: "db-host-val"}}
+
+	actual, err := source.UkaseSource(loadSpec(t))
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, actual, expected)
+}
+
+func TestMapSourceMapField(t *testing.T) {
+	type Params struct {
+		Labels map[string]string `ukflag:"labels"`
+		Limits *map[string]int   `ukflag:"limits" uksplit:""`
+	}
+
+	spec, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	source := uksrc.Map{
+		"labels": map[string]any{"b": "two", "a": "one"},
+		"limits": map[string]any{"cpu": float64(2)},
+	}
+
+	expected := []ukcore.Flag{
+		{Name: "labels", Value: "a=one"},
+		{Name: "labels", Value: "b=two"},
+		{Name: "limits", Value: "cpu=2"},
+	}
+
+	actual, err := source.UkaseSource(spec)
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, actual, expected)
+}
+
+func TestMapSourceError(t *testing.T) {
+	type IVE = uksrc.InvalidValueError
+
+	source := uksrc.Map{"lorem": map[string]any{"ipsum": "dolor"}}
+	_, err := source.UkaseSource(loadSpec(t))
+
+	assert.Check(t, itest.CmpErrorAsU[IVE](err))
+}
+
+func TestFileSource(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("json", func(t *testing.T) {
+		path := filepath.Join(dir, "config.json")
+		data := []byte(`{"lorem": "lorem-val", "db": {"port": 42}}`)
+		assert.NilError(t, os.WriteFile(path, data, 0o600))
+
+		expected := []ukcore.Flag{
+			{Name: "lorem", Value: "lorem-val"},
+			{Name: "db-port", Value: "42"},
+		}
+
+		actual, err := uksrc.NewFileJSON(path).UkaseSource(loadSpec(t))
+
+		assert.NilError(t, err)
+		assert.DeepEqual(t, actual, expected)
+	})
+
+	t.Run("optional missing", func(t *testing.T) {
+		source := uksrc.NewFileJSON(filepath.Join(dir, "missing.json"))
+		source.Optional = true
+
+		actual, err := source.UkaseSource(loadSpec(t))
+
+		assert.NilError(t, err)
+		assert.Check(t, len(actual) == 0)
+	})
+
+	t.Run("required missing", func(t *testing.T) {
+		type IFE = uksrc.InvalidFileError
+
+		source := uksrc.NewFileJSON(filepath.Join(dir, "missing.json"))
+		_, err := source.UkaseSource(loadSpec(t))
+
+		assert.Check(t, itest.CmpErrorAsU[IFE](err))
+	})
+}
@@ -0,0 +1,34 @@
+package ukvalid
+
+import (
+	"log/slog"
+
+	"github.com/oligarch316/ukase/internal/ilog"
+)
+
+// =============================================================================
+// Config
+// =============================================================================
+
+type Option interface{ UkaseApplyValid(*Config) }
+
+type Config struct {
+	// TODO: Document
+	Log *slog.Logger
+}
+
+func newConfig(opts []Option) Config {
+	config := cfgDefault
+	for _, opt := range opts {
+		opt.UkaseApplyValid(&config)
+	}
+	return config
+}
+
+// =============================================================================
+// Defaults
+// =============================================================================
+
+var cfgDefault = Config{
+	Log: ilog.Discard,
+}
@@ -0,0 +1,159 @@
+package ukvalid
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+)
+
+// =============================================================================
+// Constraint
+// =============================================================================
+
+// A check derived from a field constraint loaded by ukspec, returning the
+// offending value on failure
+type constraint struct {
+	Name  string
+	check func(reflect.Value) (string, error)
+}
+
+func newConstraint(t reflect.Type, spec ukspec.Constraint) (constraint, error) {
+	name := spec.String()
+
+	switch spec.Kind {
+	case ukspec.ConstraintMin:
+		return newConstraintBound(t, name, spec.Value, spec.Bound, "at least", func(x, bound float64) bool { return x >= bound })
+	case ukspec.ConstraintMax:
+		return newConstraintBound(t, name, spec.Value, spec.Bound, "at most", func(x, bound float64) bool { return x <= bound })
+	case ukspec.ConstraintOneOf:
+		check := func(s string) error {
+			for _, choice := range spec.Choices {
+				if s == choice {
+					return nil
+				}
+			}
+			return ierror.FmtU("must be one of %s", strings.Join(spec.Choices, ", "))
+		}
+
+		return newConstraintElem(t, name, check), nil
+	case ukspec.ConstraintRegex:
+		check := func(s string) error {
+			if spec.Pattern.MatchString(s) {
+				return nil
+			}
+			return ierror.FmtU("must match '%s'", spec.Value)
+		}
+
+		return newConstraintElem(t, name, check), nil
+	default:
+		// INTERNAL:
+		// • ❬unknown kind❭ ⇒ ukspec rejects unknown constraints during load
+		return constraint{}, ierror.FmtI("unknown constraint '%s'", spec.Kind)
+	}
+}
+
+func newConstraintBound(t reflect.Type, name, value string, bound float64, desc string, within func(x, bound float64) bool) (constraint, error) {
+	var (
+		noun    string
+		measure func(reflect.Value) float64
+	)
+
+	switch indirectType(t).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		noun, measure = "value", func(v reflect.Value) float64 { return float64(v.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		noun, measure = "value", func(v reflect.Value) float64 { return float64(v.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		noun, measure = "value", func(v reflect.Value) float64 { return v.Float() }
+	case reflect.String:
+		noun, measure = "length", func(v reflect.Value) float64 { return float64(utf8.RuneCountInString(v.String())) }
+	case reflect.Slice, reflect.Array, reflect.Map:
+		noun, measure = "length", func(v reflect.Value) float64 { return float64(v.Len()) }
+	default:
+		// INTERNAL:
+		// • ❬unsupported type❭ ⇒ ukspec rejects bounds on such types during load
+		return constraint{}, ierror.FmtI("constraint '%s' unsupported for type '%s'", name, t)
+	}
+
+	check := func(v reflect.Value) (string, error) {
+		if within(measure(v), bound) {
+			return "", nil
+		}
+		return formatValue(v), ierror.FmtU("%s must be %s %s", noun, desc, value)
+	}
+
+	return constraint{Name: name, check: check}, nil
+}
+
+func newConstraintElem(t reflect.Type, name string, check func(string) error) constraint {
+	switch indirectType(t).Kind() {
+	case reflect.Slice, reflect.Array:
+		checkElems := func(v reflect.Value) (string, error) {
+			for i := range v.Len() {
+				elemVal, ok := indirectValue(v.Index(i))
+				if !ok {
+					continue
+				}
+
+				s := formatValue(elemVal)
+				if err := check(s); err != nil {
+					return s, err
+				}
+			}
+			return "", nil
+		}
+
+		return constraint{Name: name, check: checkElems}
+	default:
+		checkValue := func(v reflect.Value) (string, error) {
+			s := formatValue(v)
+			return s, check(s)
+		}
+
+		return constraint{Name: name, check: checkValue}
+	}
+}
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func indirectValue(v reflect.Value) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+func formatValue(v reflect.Value) string {
+	if v.CanAddr() {
+		if x, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+			if text, err := x.MarshalText(); err == nil {
+				return string(text)
+			}
+		}
+	}
+
+	if x, ok := v.Interface().(encoding.TextMarshaler); ok {
+		if text, err := x.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
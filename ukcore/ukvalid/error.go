@@ -0,0 +1,46 @@
+package ukvalid
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/internal/ispec"
+	"github.com/oligarch316/ukase/ukcore"
+)
+
+var (
+	ErrInvalidField  = errors.New("invalid field error")
+	ErrInvalidParams = errors.New("invalid parameters error")
+)
+
+type InvalidFieldError[S any] struct {
+	Source     S
+	Constraint string
+	err        error
+}
+
+type InvalidParametersError struct {
+	err error
+}
+
+var errIsTagged = ierror.IsTaggedFunc(ierror.ErrValid)
+
+func (e InvalidFieldError[S]) Is(t error) bool   { return errIsTagged(t, ErrInvalidField) }
+func (e InvalidParametersError) Is(t error) bool { return errIsTagged(t, ErrInvalidParams) }
+
+func (e InvalidFieldError[S]) Unwrap() error   { return e.err }
+func (e InvalidParametersError) Unwrap() error { return e.err }
+
+func (e InvalidFieldError[S]) Error() string {
+	switch source := any(e.Source).(type) {
+	case ukcore.Flag:
+		return fmt.Sprintf("invalid value '%s' for flag '%s': %s", source.Value, ispec.LabelFlag(source.Name), e.err)
+	case ukcore.Argument:
+		return fmt.Sprintf("invalid value '%s' for argument '%d': %s", source.Value, source.Position, e.err)
+	default:
+		return e.err.Error()
+	}
+}
+
+func (e InvalidParametersError) Error() string { return e.err.Error() }
@@ -0,0 +1,257 @@
+package ukvalid
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/internal/ireflect"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukdec"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+)
+
+// =============================================================================
+// Rule
+// =============================================================================
+
+type Rule interface {
+	Register(*RuleSet)
+	apply(any) error
+}
+
+func NewRule[T any](f func(*T) error) Rule { return rule[T](f) }
+
+type rule[T any] func(*T) error
+
+func (r rule[T]) Register(ruleSet *RuleSet) {
+	t := reflect.TypeFor[T]()
+	ruleSet.rules[t] = append(ruleSet.rules[t], r)
+}
+
+func (r rule[T]) apply(v any) error {
+	if vt, ok := v.(*T); ok {
+		return r(vt)
+	}
+
+	// INTERNAL:
+	// • ❬mismatched type❭ ⇒ rules are only registered and looked up by `T`
+	return ierror.FmtI("rule value type '%T' does not match expected type '%T'", v, new(T))
+}
+
+// =============================================================================
+// RuleSet
+// =============================================================================
+
+type custom interface{ UkaseValidate() error }
+
+var typeCustom = reflect.TypeFor[custom]()
+
+type RuleSet struct {
+	config Config
+	rules  map[reflect.Type][]Rule
+}
+
+func NewRuleSet(opts ...Option) *RuleSet {
+	return &RuleSet{
+		config: newConfig(opts),
+		rules:  make(map[reflect.Type][]Rule),
+	}
+}
+
+// Process validates the decoded parameters v. Field constraints declared via
+// tag are checked first, reporting every invalid field at once, followed by
+// any `UkaseValidate` methods and registered rules. Constraints apply only to
+// fields in set or declaring a default, never to the zero value of an omitted
+// optional field.
+func (rs *RuleSet) Process(input ukcore.Input, set ukdec.FieldSet, spec ukspec.Parameters, v any) error {
+	paramsVal, err := ireflect.NewParametersValue(v)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, flagSpec := range spec.Flags {
+		if set.Contains(flagSpec.FieldIndex) || flagSpec.Default != "" {
+			errs = append(errs, rs.processFlag(input, spec, paramsVal, flagSpec))
+		}
+	}
+
+	for _, argSpec := range spec.Arguments {
+		if set.Contains(argSpec.FieldIndex) || argSpec.Default != "" {
+			errs = append(errs, rs.processArgument(input, spec, paramsVal, argSpec))
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	inlines := slices.Clone(spec.Inlines)
+	slices.SortStableFunc(inlines, rs.orderInline)
+
+	for _, inlineSpec := range inlines {
+		inlineVal, ok := rs.loadInline(paramsVal, inlineSpec.FieldIndex)
+		if !ok {
+			continue
+		}
+
+		if err := rs.processValue(inlineVal); err != nil {
+			return err
+		}
+	}
+
+	return rs.processValue(paramsVal.Addr())
+}
+
+// -----------------------------------------------------------------------------
+// RuleSet› Fields
+// -----------------------------------------------------------------------------
+
+func (rs RuleSet) processFlag(input ukcore.Input, spec ukspec.Parameters, paramsVal ireflect.ParametersValue, flagSpec ukspec.Flag) error {
+	value, constraint, err := rs.processField(paramsVal, flagSpec.FieldIndex, flagSpec.Valid)
+	if err == nil || constraint == "" {
+		return err
+	}
+
+	// Reference the flag name as typed, falling back to the longest current name
+	name := slices.MaxFunc(flagSpec.Current(), func(a, b string) int {
+		return utf8.RuneCountInString(a) - utf8.RuneCountInString(b)
+	})
+
+	for _, flag := range input.Flags {
+		if candidate, ok := spec.LookupFlag(flag.Name); ok && slices.Equal(candidate.FieldIndex, flagSpec.FieldIndex) {
+			name = flag.Name
+		}
+	}
+
+	source := ukcore.Flag{Name: name, Value: value}
+	return InvalidFieldError[ukcore.Flag]{Source: source, Constraint: constraint, err: err}
+}
+
+func (rs RuleSet) processArgument(input ukcore.Input, spec ukspec.Parameters, paramsVal ireflect.ParametersValue, argSpec ukspec.Argument) error {
+	value, constraint, err := rs.processField(paramsVal, argSpec.FieldIndex, argSpec.Valid)
+	if err == nil || constraint == "" {
+		return err
+	}
+
+	// Reference the position of the offending value, falling back to the
+	// first position of the argument
+	var position int
+	if argSpec.Position.Low != nil {
+		position = int(*argSpec.Position.Low)
+	}
+
+	for _, arg := range input.Arguments {
+		if candidate, ok := spec.LookupArgument(arg.Position); ok && slices.Equal(candidate.FieldIndex, argSpec.FieldIndex) && arg.Value == value {
+			position = arg.Position
+			break
+		}
+	}
+
+	source := ukcore.Argument{Position: position, Value: value}
+	return InvalidFieldError[ukcore.Argument]{Source: source, Constraint: constraint, err: err}
+}
+
+// Check the given constraints against the field at the given index. On
+// failure returns the offending value and constraint, or an empty constraint
+// for internal errors.
+func (rs RuleSet) processField(paramsVal ireflect.ParametersValue, index []int, constraints ukspec.Constraints) (string, string, error) {
+	if len(constraints) == 0 {
+		return "", "", nil
+	}
+
+	sField := paramsVal.Type().FieldByIndex(index)
+
+	// Unreachable field (nil intermediate pointer) ⇒ nothing to validate
+	fieldVal, err := paramsVal.FieldByIndexErr(index)
+	if err != nil {
+		return "", "", nil
+	}
+
+	// Nil field ⇒ nothing to validate
+	fieldVal, ok := indirectValue(fieldVal)
+	if !ok {
+		return "", "", nil
+	}
+
+	rs.config.Log.Debug("validating field", "type", sField.Type, "name", sField.Name)
+
+	for _, spec := range constraints {
+		constraint, err := newConstraint(sField.Type, spec)
+		if err != nil {
+			return "", "", err
+		}
+
+		if value, err := constraint.check(fieldVal); err != nil {
+			return value, constraint.Name, err
+		}
+	}
+
+	return "", "", nil
+}
+
+// -----------------------------------------------------------------------------
+// RuleSet› Values
+// -----------------------------------------------------------------------------
+
+func (rs RuleSet) processValue(val reflect.Value) error {
+	valType := val.Type()
+
+	customTrigger := valType.Implements(typeCustom)
+	rules, rulesTrigger := rs.rules[valType.Elem()]
+
+	if !customTrigger && !rulesTrigger {
+		return nil
+	}
+
+	v := val.Interface()
+
+	if customTrigger {
+		if err := v.(custom).UkaseValidate(); err != nil {
+			return InvalidParametersError{err: ierror.U(err)}
+		}
+	}
+
+	for _, rule := range rules {
+		if err := rule.apply(v); err != nil {
+			return InvalidParametersError{err: ierror.U(err)}
+		}
+	}
+
+	return nil
+}
+
+func (RuleSet) loadInline(paramsVal ireflect.ParametersValue, index []int) (reflect.Value, bool) {
+	// Load the relevant field, skipping unset (nil) intermediate fields
+	inlineVal, err := paramsVal.FieldByIndexErr(index)
+	if err != nil {
+		return inlineVal, false
+	}
+
+	// Ensure the result is a non-nil pointer, using `.Addr()` if necessary
+	switch {
+	case inlineVal.Kind() != reflect.Pointer:
+		return inlineVal.Addr(), true
+	case inlineVal.IsNil():
+		return inlineVal, false
+	default:
+		return inlineVal, true
+	}
+}
+
+func (RuleSet) orderInline(a, b ukspec.Inline) int {
+	// Bottom-up ⇒ deeper inlines are validated before their parents
+
+	switch tierA, tierB := len(a.FieldIndex), len(b.FieldIndex); {
+	case tierA > tierB:
+		return -1
+	case tierA < tierB:
+		return 1
+	default:
+		return 0
+	}
+}
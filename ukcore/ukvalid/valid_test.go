@@ -0,0 +1,236 @@
+package ukvalid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oligarch316/ukase/internal/itest"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukdec"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
+	"github.com/oligarch316/ukase/ukcore/ukvalid"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+// Process params as though every field were set
+func process[Params any](t *testing.T, ruleSet *ukvalid.RuleSet, input ukcore.Input, params Params) error {
+	spec, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	var set ukdec.FieldSet
+	for _, flagSpec := range spec.Flags {
+		set = append(set, flagSpec.FieldIndex)
+	}
+	for _, argSpec := range spec.Arguments {
+		set = append(set, argSpec.FieldIndex)
+	}
+
+	return ruleSet.Process(input, set, spec, &params)
+}
+
+type customParams struct {
+	Lorem string `ukflag:"lorem"`
+}
+
+func (cp customParams) UkaseValidate() error {
+	if cp.Lorem == "invalid" {
+		return errors.New("lorem is invalid")
+	}
+	return nil
+}
+
+// =============================================================================
+// Constraints
+// =============================================================================
+
+func TestProcessConstraints(t *testing.T) {
+	type Params struct {
+		Count  int      `ukflag:"c count" ukvalid:"min=1,max=10"`
+		Format string   `ukflag:"format" ukvalid:"oneof=json|text"`
+		Name   string   `ukflag:"name" ukvalid:"min=2,regex=^[a-z,]+$"`
+		Tags   []string `ukflag:"tag" ukvalid:"max=2,oneof=a|b|c"`
+		Ptr    *int     `ukflag:"ptr" ukvalid:"min=1"`
+		Files  []string `ukarg:":" ukvalid:"regex=\\.go$"`
+	}
+
+	type subtest struct {
+		name     string
+		input    ukcore.Input
+		params   Params
+		expected []string
+	}
+
+	valid := Params{Count: 5, Format: "json", Name: "a,b", Tags: []string{"a"}, Files: []string{"x.go"}}
+
+	with := func(f func(*Params)) Params { p := valid; f(&p); return p }
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		err := process(t, ukvalid.NewRuleSet(), st.input, st.params)
+
+		if len(st.expected) == 0 {
+			return st.name, cmp.Nil(err)
+		}
+
+		comparisons := []cmp.Comparison{itest.CmpErrorAsU[ukvalid.InvalidFieldError[ukcore.Flag]](err)}
+		for _, message := range st.expected {
+			comparisons = append(comparisons, cmp.Contains(err.Error(), message))
+		}
+
+		return st.name, itest.CmpSequence(comparisons...)
+	}
+
+	subtests := []subtest{
+		{"valid", ukcore.Input{}, valid, nil},
+		{"nil pointer", ukcore.Input{}, with(func(p *Params) { p.Ptr = nil }), nil},
+		{
+			name:     "below min",
+			input:    ukcore.Input{},
+			params:   with(func(p *Params) { p.Count = 0 }),
+			expected: []string{"invalid value '0' for flag '--count': value must be at least 1"},
+		},
+		{
+			name:     "above max as typed",
+			input:    ukcore.Input{Flags: []ukcore.Flag{{Name: "c", Value: "11"}}},
+			params:   with(func(p *Params) { p.Count = 11 }),
+			expected: []string{"invalid value '11' for flag '-c': value must be at most 10"},
+		},
+		{
+			name:     "not one of",
+			input:    ukcore.Input{},
+			params:   with(func(p *Params) { p.Format = "yaml" }),
+			expected: []string{"flag '--format': must be one of json, text"},
+		},
+		{
+			name:     "regex mismatch",
+			input:    ukcore.Input{},
+			params:   with(func(p *Params) { p.Name = "A,b" }),
+			expected: []string{"flag '--name': must match '^[a-z,]+$'"},
+		},
+		{
+			name:     "element not one of",
+			input:    ukcore.Input{},
+			params:   with(func(p *Params) { p.Tags = []string{"a", "d"} }),
+			expected: []string{"invalid value 'd' for flag '--tag'"},
+		},
+		{
+			name:     "pointer below min",
+			input:    ukcore.Input{},
+			params:   with(func(p *Params) { p.Ptr = new(int) }),
+			expected: []string{"flag '--ptr': value must be at least 1"},
+		},
+		{
+			name:   "aggregated",
+			input:  ukcore.Input{},
+			params: with(func(p *Params) { p.Count = 0; p.Format = "yaml"; p.Tags = []string{"a", "b", "c"} }),
+			expected: []string{
+				"flag '--count': value must be at least 1",
+				"flag '--format': must be one of json, text",
+				"flag '--tag': length must be at most 2",
+			},
+		},
+	}
+
+	itest.Run(t, runner, subtests...)
+}
+
+func TestProcessConstraintsArgument(t *testing.T) {
+	type Params struct {
+		Files []string `ukarg:":" ukvalid:"regex=\\.go$"`
+	}
+
+	input := ukcore.Input{Arguments: []ukcore.Argument{{Position: 0, Value: "a.go"}, {Position: 1, Value: "b.txt"}}}
+	params := Params{Files: []string{"a.go", "b.txt"}}
+
+	err := process(t, ukvalid.NewRuleSet(), input, params)
+
+	var actual ukvalid.InvalidFieldError[ukcore.Argument]
+	assert.Assert(t, itest.CmpErrorAsU[ukvalid.InvalidFieldError[ukcore.Argument]](err))
+	assert.Assert(t, errors.As(err, &actual))
+	assert.Check(t, cmp.Equal(actual.Source.Position, 1))
+	assert.Check(t, cmp.Equal(actual.Source.Value, "b.txt"))
+}
+
+func TestProcessConstraintsOmitted(t *testing.T) {
+	type Params struct {
+		Port    int    `ukflag:"port" ukvalid:"min=1"`
+		Pattern string `ukflag:"pattern" ukvalid:"regex=^[a-z]+$"`
+		Mode    string `ukflag:"mode" ukdefault:"Fast" ukvalid:"oneof=fast|slow"`
+		File    string `ukarg:"0" ukvalid:"regex=\\.go$"`
+	}
+
+	spec, err := ukspec.ParametersFor[Params]()
+	assert.NilError(t, err)
+
+	t.Run("omitted", func(t *testing.T) {
+		// • Expect› Zero values of omitted fields are not validated
+		params := Params{Mode: "fast"}
+		err := ukvalid.NewRuleSet().Process(ukcore.Input{}, nil, spec, &params)
+		assert.NilError(t, err)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		params := Params{Mode: "fast"}
+		set := ukdec.FieldSet{spec.Flags[0].FieldIndex}
+
+		err := ukvalid.NewRuleSet().Process(ukcore.Input{}, set, spec, &params)
+		assert.Check(t, cmp.ErrorContains(err, "invalid value '0' for flag '--port'"))
+	})
+
+	t.Run("default", func(t *testing.T) {
+		// • Expect› Fields declaring a default are validated
+		params := Params{Mode: "Fast"}
+		err := ukvalid.NewRuleSet().Process(ukcore.Input{}, nil, spec, &params)
+		assert.Check(t, cmp.ErrorContains(err, "flag '--mode': must be one of fast, slow"))
+	})
+}
+
+// =============================================================================
+// Rules
+// =============================================================================
+
+func TestProcessRules(t *testing.T) {
+	type Inner struct {
+		Ipsum int `ukflag:"ipsum"`
+	}
+
+	type Params struct {
+		Custom customParams `ukinline:"custom-"`
+		Inner  *Inner       `ukinline:"inner-"`
+	}
+
+	ruleSet := ukvalid.NewRuleSet()
+
+	ukvalid.NewRule(func(i *Inner) error {
+		if i.Ipsum < 0 {
+			return errors.New("ipsum is negative")
+		}
+		return nil
+	}).Register(ruleSet)
+
+	t.Run("valid", func(t *testing.T) {
+		err := process(t, ruleSet, ukcore.Input{}, Params{Inner: &Inner{Ipsum: 1}})
+		assert.NilError(t, err)
+	})
+
+	t.Run("nil inline", func(t *testing.T) {
+		err := process(t, ruleSet, ukcore.Input{}, Params{})
+		assert.NilError(t, err)
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		err := process(t, ruleSet, ukcore.Input{}, Params{Custom: customParams{Lorem: "invalid"}})
+		assert.Check(t, itest.CmpErrorAsU[ukvalid.InvalidParametersError](err))
+		assert.Check(t, cmp.ErrorContains(err, "lorem is invalid"))
+	})
+
+	t.Run("rule", func(t *testing.T) {
+		err := process(t, ruleSet, ukcore.Input{}, Params{Inner: &Inner{Ipsum: -1}})
+		assert.Check(t, itest.CmpErrorAsU[ukvalid.InvalidParametersError](err))
+		assert.Check(t, cmp.ErrorContains(err, "ipsum is negative"))
+	})
+}
@@ -14,8 +14,10 @@ var (
 
 // TODO: Document
 var (
-	ErrDec  = ierror.ErrDec
-	ErrExec = ierror.ErrExec
-	ErrInit = ierror.ErrInit
-	ErrSpec = ierror.ErrSpec
+	ErrDec   = ierror.ErrDec
+	ErrExec  = ierror.ErrExec
+	ErrInit  = ierror.ErrInit
+	ErrSpec  = ierror.ErrSpec
+	ErrSrc   = ierror.ErrSrc
+	ErrValid = ierror.ErrValid
 )
@@ -8,6 +8,10 @@ import (
 	"github.com/oligarch316/ukase/ukcore/ukspec"
 )
 
+// =============================================================================
+// Builder
+// =============================================================================
+
 type Builder[Params any] func(refTarget ...string) (exec ukcli.Exec[Params], info any)
 
 func NewBuilder[Params any](builder func(...string) (ukcli.Exec[Params], any)) Builder[Params] {
@@ -15,67 +19,86 @@ func NewBuilder[Params any](builder func(...string) (ukcli.Exec[Params], any)) B
 }
 
 func (b Builder[Params]) Auto(name string) func(ukcli.State) ukcli.State {
-	return func(s ukcli.State) ukcli.State {
-		return &autoState[Params]{State: s, builder: b, name: name}
+	directives := func(path []string) []ukcli.Directive {
+		return []ukcli.Directive{b.Bind(name, path...)}
 	}
-}
-
-type autoTree map[string]autoTree
-
-type autoState[Params any] struct {
-	ukcli.State
 
-	builder Builder[Params]
-	name    string
-	memo    autoTree
+	return Auto(directives)
 }
 
-func (as *autoState[Params]) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...string) error {
-	if err := as.State.RegisterExec(exec, spec, target...); err != nil {
-		return err
-	}
-
-	return as.registerMeta(target)
-}
+// Bind the meta command built for the given path beneath that path as name
+func (b Builder[Params]) Bind(name string, path ...string) ukcli.Directive {
+	exec, info := b(path...)
+	target := append(slices.Clip(path), name)
 
-func (as *autoState[Params]) registerMeta(target []string) error {
-	for _, path := range as.sift(target) {
-		metaTarget := append(path, as.name)
-		exec, info := as.builder(path...)
-
-		if err := as.registerMetaExec(exec, metaTarget); err != nil {
+	dir := func(s ukcli.State) error {
+		if err := s.RegisterMeta(target...); err != nil {
 			return err
 		}
 
-		if err := as.registerMetaInfo(info, metaTarget); err != nil {
+		if err := exec.Bind(target...).UkaseRegister(s); err != nil {
 			return err
 		}
+
+		if info == nil {
+			return nil
+		}
+
+		return ukcli.NewInfo(info).Bind(target...).UkaseRegister(s)
 	}
 
-	return nil
+	return ukcli.NewDirective(dir)
 }
 
-func (as *autoState[Params]) registerMetaExec(exec ukcli.Exec[Params], target []string) error {
-	if exec == nil {
-		return nil
+// =============================================================================
+// Auto
+// › Registers meta commands beneath each path as execs are registered
+// › All directives of a single path are registered by one middleware, so that
+//   meta commands are never registered beneath one another
+// =============================================================================
+
+func Auto(directives func(path []string) []ukcli.Directive) func(ukcli.State) ukcli.State {
+	return func(s ukcli.State) ukcli.State {
+		return &autoState{State: s, directives: directives}
 	}
+}
 
-	metaExec := exec.Bind(target...)
-	return metaExec.UkaseRegister(as.State)
+// AutoOnce registers the given directives once, upon the first registered
+// exec, eg. meta commands beneath the root only
+func AutoOnce(directives ...ukcli.Directive) func(ukcli.State) ukcli.State {
+	return func(s ukcli.State) ukcli.State {
+		return &autoOnceState{State: s, directives: directives}
+	}
 }
 
-func (as *autoState[Params]) registerMetaInfo(info any, target []string) error {
-	if info == nil {
-		return nil
+type autoTree map[string]autoTree
+
+type autoState struct {
+	ukcli.State
+
+	directives func(path []string) []ukcli.Directive
+	memo       autoTree
+}
+
+func (as *autoState) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...string) error {
+	if err := as.State.RegisterExec(exec, spec, target...); err != nil {
+		return err
+	}
+
+	for _, path := range as.sift(target) {
+		for _, directive := range as.directives(path) {
+			if err := directive.UkaseRegister(as.State); err != nil {
+				return err
+			}
+		}
 	}
 
-	metaInfo := ukcli.NewInfo(info).Bind(target...)
-	return metaInfo.UkaseRegister(as.State)
+	return nil
 }
 
 // Ensure each sub-path of the given target is marked as visited.
 // Return a list of those that have not previously been visited.
-func (as *autoState[Params]) sift(target []string) [][]string {
+func (as *autoState) sift(target []string) [][]string {
 	var paths [][]string
 
 	if as.memo == nil {
@@ -109,3 +132,30 @@ func (as *autoState[Params]) sift(target []string) [][]string {
 
 	return paths
 }
+
+type autoOnceState struct {
+	ukcli.State
+
+	directives []ukcli.Directive
+	done       bool
+}
+
+func (as *autoOnceState) RegisterExec(exec ukcore.Exec, spec ukspec.Parameters, target ...string) error {
+	if err := as.State.RegisterExec(exec, spec, target...); err != nil {
+		return err
+	}
+
+	if as.done {
+		return nil
+	}
+
+	as.done = true
+
+	for _, directive := range as.directives {
+		if err := directive.UkaseRegister(as.State); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
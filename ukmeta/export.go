@@ -0,0 +1,96 @@
+package ukmeta
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcore"
+	"github.com/oligarch316/ukase/ukcore/ukexec"
+)
+
+// =============================================================================
+// Export
+// › Walks the command tree on behalf of exporters writing one document per
+//   command, eg. man pages or markdown
+// › Hidden and meta commands (help, completion, …) are not exported
+// =============================================================================
+
+type ExportNode struct {
+	// Input referencing the command
+	Input Input
+
+	// Target path of the command
+	Path []string
+
+	// Names of exported child commands, sorted
+	Children []string
+}
+
+// Export visits the root and each exported descendant command, parents before
+// children and siblings in order of name
+func Export(in ukcli.Input, visit func(ExportNode) error) error {
+	root, err := in.Lookup()
+	if err != nil {
+		return err
+	}
+
+	// Exported documents are installed or published apart from the binary
+	// ⇒ Refer to the program by name rather than invocation path
+	core := in.Core()
+	core.Program = filepath.Base(core.Program)
+
+	exportIn := exportInput{Input: in, core: core}
+	return exportNode(exportIn, nil, root, visit)
+}
+
+func exportNode(in ukcli.Input, path []string, meta ukexec.Meta, visit func(ExportNode) error) error {
+	children := meta.Children()
+
+	var childNames []string
+	for _, name := range SortedKeys(children) {
+		if child := children[name]; !child.Hidden && !child.Meta {
+			childNames = append(childNames, name)
+		}
+	}
+
+	nodeIn, err := NewInput(in, path...)
+	if err != nil {
+		return err
+	}
+
+	node := ExportNode{Input: nodeIn, Path: path, Children: childNames}
+	if err := visit(node); err != nil {
+		return err
+	}
+
+	for _, name := range childNames {
+		childPath := append(slices.Clip(path), name)
+		if err := exportNode(in, childPath, children[name], visit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type exportInput struct {
+	ukcli.Input
+	core ukcore.Input
+}
+
+func (ei exportInput) Core() ukcore.Input { return ei.core }
+
+// =============================================================================
+// Utility
+// =============================================================================
+
+func SortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package ukcomp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukmeta"
+)
+
+type Params struct {
+	Shell string `ukarg:"0"`
+}
+
+type DynamicParams struct {
+	Values []string `ukarg:":"`
+}
+
+type Builder struct{ config Config }
+
+func NewBuilder(opts ...Option) Builder {
+	config := newConfig(opts)
+	return Builder{config: config}
+}
+
+// =============================================================================
+// Build
+// =============================================================================
+
+func (b Builder) Build() (ukcli.Exec[Params], any) {
+	exec := func(ctx context.Context, in ukcli.Input) error {
+		var params Params
+
+		if err := in.Decode(&params); err != nil {
+			return err
+		}
+
+		compData, err := b.config.Encode(in)
+		if err != nil {
+			return err
+		}
+
+		compData.Dynamic = b.config.DynamicCommand
+
+		return b.config.Render(ctx, params.Shell, compData)
+	}
+
+	return exec, b.config.Info
+}
+
+func (b Builder) Bind(target ...string) ukcli.Directive {
+	return ukcli.NewDirective(func(s ukcli.State) error {
+		exec, info := b.Build()
+
+		if err := s.RegisterMeta(target...); err != nil {
+			return err
+		}
+
+		if err := exec.Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		if err := ukcli.NewInfo(info).Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		return s.RegisterHidden(target...)
+	})
+}
+
+func (b Builder) BuildDynamic() ukcli.Exec[DynamicParams] {
+	return func(ctx context.Context, in ukcli.Input) error {
+		var params DynamicParams
+
+		if err := in.Decode(&params); err != nil {
+			return err
+		}
+
+		completion, err := in.Complete(params.Values...)
+		if err != nil {
+			return err
+		}
+
+		for _, candidate := range b.config.DynamicComplete(completion) {
+			if _, err := fmt.Fprintln(b.config.DynamicOut, candidate); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func (b Builder) BindDynamic(target ...string) ukcli.Directive {
+	return ukcli.NewDirective(func(s ukcli.State) error {
+		if err := s.RegisterMeta(target...); err != nil {
+			return err
+		}
+
+		if err := b.BuildDynamic().Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		return s.RegisterHidden(target...)
+	})
+}
+
+// =============================================================================
+// Auto
+// › Registers the completion commands beneath the root upon first registration
+// =============================================================================
+
+func (b Builder) Auto(name string) func(ukcli.State) ukcli.State {
+	directives := []ukcli.Directive{b.Bind(name)}
+
+	if b.config.DynamicCommand != "" {
+		directives = append(directives, b.BindDynamic(b.config.DynamicCommand))
+	}
+
+	return ukmeta.AutoOnce(directives...)
+}
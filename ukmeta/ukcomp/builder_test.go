@@ -0,0 +1,142 @@
+package ukcomp_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/ukase/internal/itest"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukmeta/ukcomp"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type paramsRoot struct {
+	Verbose bool `ukflag:"v verbose"`
+}
+
+type paramsCopy struct {
+	Force bool     `ukflag:"f force"`
+	Mode  string   `ukflag:"m mode"`
+	Files []string `ukarg:":"`
+}
+
+type paramsDeploy struct {
+	Region string `ukflag:"region é"`
+	Old    string `ukflag:"zone" ukhide:""`
+}
+
+type compOption func(*ukcomp.Config)
+
+func (o compOption) UkaseApplyComp(c *ukcomp.Config) { o(c) }
+
+func handleNoop[Params any](context.Context, Params) error { return nil }
+
+func runComp(shell string, opts ...ukcomp.Option) (string, error) {
+	var out strings.Builder
+
+	render := compOption(func(c *ukcomp.Config) { c.Render = ukcomp.NewRenderer(&out) })
+	builder := ukcomp.NewBuilder(append(opts, render)...)
+
+	runtime := ukcli.NewRuntime()
+	runtime.Add(
+		ukcli.NewHandler(handleNoop[paramsRoot]).Bind(),
+		ukcli.NewHandler(handleNoop[paramsCopy]).Bind("copy"),
+		ukcli.NewHandler(handleNoop[paramsDeploy]).Bind("deploy", "now"),
+		builder.Bind("completion"),
+	)
+
+	err := runtime.Execute(context.Background(), []string{"./bin/my-tool", "completion", shell})
+	return out.String(), err
+}
+
+// =============================================================================
+// Render
+// =============================================================================
+
+func TestRenderScript(t *testing.T) {
+	type subtest struct {
+		name     string
+		shell    string
+		opts     []ukcomp.Option
+		expected []string
+	}
+
+	// Lines common to the bash and zsh case tables
+	shTables := []string{
+		`        '') echo 'copy deploy' ;;`,
+		`        'copy') echo '' ;;`,
+		`        'deploy now') echo '' ;;`,
+
+		`        '') echo '--verbose -v' ;;`,
+		`        'copy') echo '--force --mode -f -m' ;;`,
+		`        'deploy now') echo '--region -é' ;;`,
+
+		`        '') echo '--mode --region --zone -m -é' ;;`,
+		`        'copy') echo '--mode -m' ;;`,
+	}
+
+	runner := func(st subtest) (string, cmp.Comparison) {
+		actual, err := runComp(st.shell, st.opts...)
+
+		seq := []cmp.Comparison{cmp.Nil(err)}
+		for _, line := range st.expected {
+			seq = append(seq, cmp.Contains(actual, line+"\n"))
+		}
+
+		return st.name, itest.CmpSequence(seq...)
+	}
+
+	itest.Run(t, runner,
+		subtest{
+			name:  "bash",
+			shell: "bash",
+			expected: append([]string{
+				"# bash completion for my-tool",
+				"_my_tool_commands() {",
+				`        mapfile -t COMPREPLY < <("${COMP_WORDS[0]}" '__complete' -- "${COMP_WORDS[@]:0:COMP_CWORD+1}" 2>/dev/null)`,
+				"complete -o default -F _my_tool 'my-tool'",
+			}, shTables...),
+		},
+		subtest{
+			name:  "zsh",
+			shell: "zsh",
+			expected: append([]string{
+				"#compdef my-tool",
+				"_my_tool_commands() {",
+				`        candidates=(${(f)"$("${words[1]}" '__complete' -- "${(@)words[1,CURRENT]}" 2>/dev/null)"})`,
+				"compdef _my_tool 'my-tool'",
+			}, shTables...),
+		},
+		subtest{
+			name:  "bash static",
+			shell: "bash",
+			opts:  []ukcomp.Option{compOption(func(c *ukcomp.Config) { c.DynamicCommand = "" })},
+			expected: []string{
+				"        COMPREPLY=()",
+				"    elif [[ \"$cur\" == -* ]]; then",
+			},
+		},
+		subtest{
+			name:  "zsh static",
+			shell: "zsh",
+			opts:  []ukcomp.Option{compOption(func(c *ukcomp.Config) { c.DynamicCommand = "" })},
+			expected: []string{
+				"        candidates=()",
+				"    elif [[ \"$cur\" == -* ]]; then",
+			},
+		},
+	)
+}
+
+func TestRenderScriptError(t *testing.T) {
+	_, err := runComp("tcsh")
+
+	assert.Check(t, itest.CmpErrorIsU(err))
+	assert.Check(t, cmp.ErrorContains(err, "unsupported shell 'tcsh' (expected one of: bash, fish, zsh)"))
+}
@@ -0,0 +1,127 @@
+package ukcomp
+
+import (
+	_ "embed"
+
+	"context"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcore/ukexec"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+// =============================================================================
+// Config
+// =============================================================================
+
+type Option interface{ UkaseApplyComp(*Config) }
+
+type Config struct {
+	Info   any
+	Encode func(in ukcli.Input) (Output, error)
+	Render func(ctx context.Context, shell string, data Output) error
+
+	DynamicCommand  string
+	DynamicComplete func(c ukexec.Completion) []string
+	DynamicOut      io.Writer
+}
+
+func newConfig(opts []Option) Config {
+	config := cfgDefault
+	for _, opt := range opts {
+		opt.UkaseApplyComp(&config)
+	}
+	return config
+}
+
+// =============================================================================
+// Defaults
+// =============================================================================
+
+var cfgDefault = Config{
+	Info:   "Generate shell completion scripts",
+	Encode: Encode,
+	Render: cfgRender,
+
+	DynamicCommand:  "__complete",
+	DynamicComplete: Candidates,
+	DynamicOut:      os.Stdout,
+}
+
+var cfgRender = NewRenderer(os.Stdout)
+
+// Render completion scripts from the built-in shell templates
+func NewRenderer(out io.Writer) func(ctx context.Context, shell string, data Output) error {
+	return func(ctx context.Context, shell string, data Output) error {
+		renderer, ok := cfgTemplates[shell]
+		if !ok {
+			shells := ukmeta.SortedKeys(cfgTemplates)
+			return ierror.FmtU("unsupported shell '%s' (expected one of: %s)", shell, strings.Join(shells, ", "))
+		}
+
+		renderer.Out = out
+		return renderer.Render(data)
+	}
+}
+
+var (
+	//go:embed render_bash.tmpl
+	cfgTemplateTextBash string
+
+	//go:embed render_fish.tmpl
+	cfgTemplateTextFish string
+
+	//go:embed render_zsh.tmpl
+	cfgTemplateTextZsh string
+)
+
+var cfgTemplates = map[string]ukhelp.TemplateRenderer{
+	"bash": {Name: "bash", Text: cfgTemplateTextBash, Funcs: cfgRenderFuncs},
+	"fish": {Name: "fish", Text: cfgTemplateTextFish, Funcs: cfgRenderFuncs},
+	"zsh":  {Name: "zsh", Text: cfgTemplateTextZsh, Funcs: cfgRenderFuncs},
+}
+
+var cfgRenderFuncs = template.FuncMap{
+	"ident":     renderIdent,
+	"key":       renderKey,
+	"shQuote":   renderShQuote,
+	"fishQuote": renderFishQuote,
+	"shWords":   renderShWords,
+	"fishWords": renderFishWords,
+}
+
+// -----------------------------------------------------------------------------
+// ❭ Render Functions
+// -----------------------------------------------------------------------------
+
+var renderIdentInvalid = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+func renderIdent(program string) string { return renderIdentInvalid.ReplaceAllString(program, "_") }
+
+func renderKey(path []string) string { return strings.Join(path, " ") }
+
+func renderShQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func renderFishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+func renderShWords(list []string) string { return renderShQuote(strings.Join(list, " ")) }
+
+func renderFishWords(list []string) string {
+	quoted := slices.Clone(list)
+	for i, item := range quoted {
+		quoted[i] = renderFishQuote(item)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,95 @@
+package ukcomp
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/oligarch316/ukase/internal/ispec"
+	"github.com/oligarch316/ukase/ukcore/ukexec"
+)
+
+// =============================================================================
+// Candidates
+// =============================================================================
+
+// Candidates lists completion values for the given context. Subcommand and
+// flag names are filtered by prefix. Flag and argument values are delegated to
+// an optional `UkaseComplete(prefix string) []string` method on the field type,
+// falling back to its `UkaseChoices() []string` filtered by prefix.
+func Candidates(c ukexec.Completion) []string {
+	switch c.Kind {
+	case ukexec.CompleteCommand:
+		list := candidatesCommand(c)
+
+		if argSpec, ok := c.Meta.Spec.LookupArgument(c.Position); ok {
+			list = append(list, candidatesField(argSpec.FieldType, argSpec.Choices, c.Prefix)...)
+		}
+
+		return list
+	case ukexec.CompleteFlagName:
+		return candidatesFlagName(c)
+	case ukexec.CompleteFlagValue:
+		return candidatesField(c.Flag.FieldType, c.Flag.Choices, c.Prefix)
+	case ukexec.CompleteArgument:
+		if argSpec, ok := c.Meta.Spec.LookupArgument(c.Position); ok {
+			return candidatesField(argSpec.FieldType, argSpec.Choices, c.Prefix)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+func candidatesCommand(c ukexec.Completion) []string {
+	var list []string
+
+	for name, meta := range c.Meta.Children() {
+		if !meta.Hidden && strings.HasPrefix(name, c.Prefix) {
+			list = append(list, name)
+		}
+	}
+
+	slices.Sort(list)
+	return list
+}
+
+func candidatesFlagName(c ukexec.Completion) []string {
+	var list []string
+
+	for _, name := range c.FlagNames {
+		if label := ispec.LabelFlag(name); strings.HasPrefix(label, c.Prefix) {
+			list = append(list, label)
+		}
+	}
+
+	return list
+}
+
+func candidatesField(t reflect.Type, choices []string, prefix string) []string {
+	type completer interface{ UkaseComplete(string) []string }
+
+	for t != nil {
+		if x, ok := reflect.New(t).Interface().(completer); ok {
+			return x.UkaseComplete(prefix)
+		}
+
+		// Containers and pointers ⇒ defer to the element type
+		switch t.Kind() {
+		case reflect.Array, reflect.Pointer, reflect.Slice:
+			t = t.Elem()
+		default:
+			t = nil
+		}
+	}
+
+	var list []string
+
+	for _, choice := range choices {
+		if strings.HasPrefix(choice, prefix) {
+			list = append(list, choice)
+		}
+	}
+
+	return list
+}
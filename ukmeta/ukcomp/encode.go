@@ -0,0 +1,78 @@
+package ukcomp
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/oligarch316/ukase/internal/ispec"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcore/ukexec"
+	"github.com/oligarch316/ukase/ukmeta"
+)
+
+// =============================================================================
+// Encode
+// =============================================================================
+
+func Encode(in ukcli.Input) (Output, error) {
+	root, err := in.Lookup()
+	if err != nil {
+		return Output{}, err
+	}
+
+	nodes, _ := encodeNode(nil, root)
+
+	output := Output{
+		Program: filepath.Base(in.Core().Program),
+		Nodes:   nodes,
+	}
+
+	return output, nil
+}
+
+// Encode the given node and all visible descendants in depth first order.
+// Flags requiring a value are collected from descendants as well, since the
+// parser accepts descendant flags ahead of their subcommand names.
+func encodeNode(path []string, meta ukexec.Meta) (nodes []OutputNode, valued []string) {
+	node := OutputNode{Path: path}
+
+	for _, spec := range meta.Spec.Flags {
+		for _, name := range spec.Names {
+			label := ispec.LabelFlag(name)
+
+			// Hidden and deprecated flags are not offered, but their values
+			// must still be skipped
+			if !spec.Hidden && !spec.IsDeprecated(name) {
+				node.Flags = append(node.Flags, label)
+			}
+
+			if !spec.Elide.Allow {
+				valued = append(valued, label)
+			}
+		}
+	}
+
+	children := meta.Children()
+
+	for _, name := range ukmeta.SortedKeys(children) {
+		child := children[name]
+		if child.Hidden {
+			continue
+		}
+
+		childPath := append(slices.Clip(path), name)
+		childNodes, childValued := encodeNode(childPath, child)
+
+		node.Commands = append(node.Commands, name)
+		nodes = append(nodes, childNodes...)
+		valued = append(valued, childValued...)
+	}
+
+	slices.Sort(node.Flags)
+	slices.Sort(valued)
+
+	node.Valued = slices.Compact(valued)
+	valued = slices.Clone(node.Valued)
+
+	return append([]OutputNode{node}, nodes...), valued
+}
@@ -0,0 +1,14 @@
+package ukcomp
+
+type Output struct {
+	Program string
+	Dynamic string
+	Nodes   []OutputNode
+}
+
+type OutputNode struct {
+	Path     []string
+	Commands []string
+	Flags    []string
+	Valued   []string
+}
@@ -0,0 +1,135 @@
+package ukdoc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+type Params struct {
+	Dir string `ukarg:"0" ukdefault:"."`
+}
+
+type Builder struct{ config Config }
+
+func NewBuilder(opts ...Option) Builder {
+	config := newConfig(opts)
+	return Builder{config: config}
+}
+
+// =============================================================================
+// Export
+// › Writes one page per exported command, in a directory tree mirroring the
+//   command tree, eg. "copy/index.md"
+// =============================================================================
+
+func (b Builder) Export(in ukcli.Input, dir string) error {
+	export := func(node ukmeta.ExportNode) error {
+		page, err := b.encodePage(node)
+		if err != nil {
+			return err
+		}
+
+		pageDir := filepath.Join(append([]string{dir}, node.Path...)...)
+		return b.writePage(pageDir, page)
+	}
+
+	return ukmeta.Export(in, export)
+}
+
+func (b Builder) encodePage(node ukmeta.ExportNode) (Page, error) {
+	helpData, err := b.config.Encode(node.Input)
+	if err != nil {
+		return Page{}, err
+	}
+
+	help, ok := helpData.(ukhelp.Output[ukinfo.Description])
+	if !ok {
+		return Page{}, ierror.FmtD("unexpected help data type '%T'", helpData)
+	}
+
+	// Subcommands not exported (meta commands) have no page to link to
+	help.Subcommands = slices.DeleteFunc(help.Subcommands, func(item ukhelp.OutputSubcommand[ukinfo.Description]) bool {
+		return !slices.Contains(node.Children, item.Name)
+	})
+
+	page := Page{Path: node.Path, Index: b.config.Index, Help: help}
+	return page, nil
+}
+
+func (b Builder) writePage(dir string, page Page) (err error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filepath.Join(dir, page.Index))
+	if err != nil {
+		return err
+	}
+
+	defer func() { err = errors.Join(err, file.Close()) }()
+
+	renderer := b.config.Template
+	renderer.Out = file
+	return renderer.Render(page)
+}
+
+// =============================================================================
+// Build
+// =============================================================================
+
+func (b Builder) Build() (ukcli.Exec[Params], any) {
+	exec := func(ctx context.Context, in ukcli.Input) error {
+		var params Params
+
+		if err := in.Initialize(&params); err != nil {
+			return err
+		}
+
+		if err := in.Decode(&params); err != nil {
+			return err
+		}
+
+		return b.Export(in, params.Dir)
+	}
+
+	return exec, b.config.Info
+}
+
+func (b Builder) Bind(target ...string) ukcli.Directive {
+	return ukcli.NewDirective(func(s ukcli.State) error {
+		exec, info := b.Build()
+
+		if err := s.RegisterMeta(target...); err != nil {
+			return err
+		}
+
+		if err := exec.Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		if err := ukcli.NewInfo(info).Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		return s.RegisterHidden(target...)
+	})
+}
+
+// =============================================================================
+// Auto
+// › Registers the (hidden) documentation command beneath the root upon first
+//   registration
+// =============================================================================
+
+func (b Builder) Auto(name string) func(ukcli.State) ukcli.State {
+	return ukmeta.AutoOnce(b.Bind(name))
+}
@@ -0,0 +1,121 @@
+package ukdoc_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukdoc"
+	"github.com/oligarch316/ukase/ukmeta/ukgen"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+	"github.com/oligarch316/ukase/ukopt"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type paramsDeploy struct {
+	Region string   `ukflag:"region"`
+	Hosts  []string `ukarg:":"`
+}
+
+// Generated form of the parameter types above, as written by ukgen
+type (
+	infoRoot   struct{}
+	infoDeploy struct {
+		Region ukinfo.Any `ukidx:"0"`
+		Hosts  ukinfo.Any `ukidx:"1"`
+	}
+)
+
+func (i *infoDeploy) UkaseInit() {
+	i.Region = "Region to deploy to"
+	i.Hosts = "Hosts to deploy"
+}
+
+var paramsMap = make(ukgen.ParamsMap)
+
+func init() {
+	ukgen.ParamsMapAdd[struct{}, infoRoot](paramsMap)
+	ukgen.ParamsMapAdd[paramsDeploy, infoDeploy](paramsMap)
+}
+
+func encodeHelp(in ukmeta.Input) (any, error) {
+	genInput := paramsMap.NewInput(in)
+	genEncoder := ukgen.Encoder[ukinfo.Description](ukinfo.Encode)
+	return genEncoder.Encode(genInput)
+}
+
+type cliOption func(*ukcli.Config)
+
+func (o cliOption) UkaseApplyCLI(c *ukcli.Config) { o(c) }
+
+func handleNoop[Params any](context.Context, Params) error { return nil }
+
+func runExport(t *testing.T, opts ...ukdoc.Option) string {
+	dir := t.TempDir()
+
+	helpAuto := ukhelp.NewBuilder().Auto("help")
+	middleware := cliOption(func(c *ukcli.Config) { c.Middleware = append(c.Middleware, helpAuto) })
+
+	runtime := ukcli.NewRuntime(middleware)
+	runtime.Add(
+		ukcli.NewHandler(handleNoop[struct{}]).Bind(),
+		ukcli.NewHandler(handleNoop[paramsDeploy]).Bind("deploy"),
+		ukdoc.NewBuilder(opts...).Bind("docs"),
+	)
+
+	err := runtime.Execute(context.Background(), []string{"./bin/my-tool", "docs", dir})
+	assert.NilError(t, err)
+
+	return dir
+}
+
+func readPage(t *testing.T, dir string, path ...string) string {
+	data, err := os.ReadFile(filepath.Join(append([]string{dir}, path...)...))
+	assert.NilError(t, err)
+	return string(data)
+}
+
+// =============================================================================
+// Export
+// =============================================================================
+
+func TestExport(t *testing.T) {
+	dir := runExport(t)
+
+	var pages []string
+	walk := func(path string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			pages = append(pages, filepath.ToSlash(rel))
+		}
+		return err
+	}
+
+	assert.NilError(t, filepath.WalkDir(dir, walk))
+
+	// • Expect› Meta (help) and hidden (docs) commands have no pages
+	assert.Check(t, cmp.DeepEqual(pages, []string{"deploy/index.md", "index.md"}))
+
+	root := readPage(t, dir, "index.md")
+	assert.Check(t, cmp.Contains(root, "](deploy/index.md)"))
+	assert.Check(t, !strings.Contains(root, "help"))
+}
+
+func TestExportEncode(t *testing.T) {
+	dir := runExport(t, ukopt.DocEncode(encodeHelp))
+
+	page := readPage(t, dir, "deploy", "index.md")
+	assert.Check(t, cmp.Contains(page, "Region to deploy to"))
+	assert.Check(t, cmp.Contains(page, "Hosts to deploy"))
+}
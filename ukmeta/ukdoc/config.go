@@ -0,0 +1,90 @@
+package ukdoc
+
+import (
+	_ "embed"
+
+	"encoding/json"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+// =============================================================================
+// Config
+// =============================================================================
+
+type Option interface{ UkaseApplyDoc(*Config) }
+
+type Config struct {
+	// TODO: Document
+	Info any
+
+	// TODO: Document
+	Index string
+
+	// TODO: Document
+	Encode func(in ukmeta.Input) (any, error)
+
+	// TODO: Document
+	Template ukhelp.TemplateRenderer
+}
+
+func newConfig(opts []Option) Config {
+	config := cfgDefault
+	for _, opt := range opts {
+		opt.UkaseApplyDoc(&config)
+	}
+	return config
+}
+
+// =============================================================================
+// Defaults
+// =============================================================================
+
+var cfgDefault = Config{
+	Info:     "Generate markdown documentation",
+	Index:    "index.md",
+	Encode:   cfgEncode,
+	Template: cfgTemplate,
+}
+
+func cfgEncode(in ukmeta.Input) (any, error) {
+	encoder := ukhelp.NewEncoder(ukinfo.Encode)
+	return encoder.Encode(in)
+}
+
+var cfgTemplate = ukhelp.TemplateRenderer{
+	Name:  "doc",
+	Text:  cfgTemplateText,
+	Out:   os.Stdout,
+	Funcs: cfgRenderFuncs(),
+}
+
+//go:embed render.tmpl
+var cfgTemplateText string
+
+func cfgRenderFuncs() template.FuncMap {
+	funcs := ukhelp.NewRenderFuncs(ukinfo.Render).Map()
+	funcs["quote"] = renderQuote
+	funcs["indent"] = renderIndent
+	return funcs
+}
+
+// -----------------------------------------------------------------------------
+// ❭ Render Functions
+// -----------------------------------------------------------------------------
+
+// Quote text as a JSON string, which doubles as a valid YAML scalar
+func renderQuote(s string) (string, error) {
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+// Indent all but the first line of text, such that it continues a list item
+func renderIndent(n int, s string) string {
+	return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", n))
+}
@@ -0,0 +1,12 @@
+package ukdoc
+
+import (
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+type Page struct {
+	Path  []string
+	Index string
+	Help  ukhelp.Output[ukinfo.Description]
+}
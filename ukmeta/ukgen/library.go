@@ -3,7 +3,6 @@ package ukgen
 import (
 	"fmt"
 	"reflect"
-	"slices"
 	"strconv"
 	"sync"
 
@@ -160,6 +159,11 @@ func (e Encoder[T]) Encode(in Input) (ukhelp.Output[T], error) {
 		return ukhelp.Output[T]{}, err
 	}
 
+	groups, err := e.EncodeGroups(in)
+	if err != nil {
+		return ukhelp.Output[T]{}, err
+	}
+
 	arguments, err := e.EncodeArguments(in)
 	if err != nil {
 		return ukhelp.Output[T]{}, err
@@ -169,6 +173,7 @@ func (e Encoder[T]) Encode(in Input) (ukhelp.Output[T], error) {
 		Command:     command,
 		Subcommands: subcommands,
 		Flags:       flags,
+		Groups:      groups,
 		Arguments:   arguments,
 	}
 
@@ -189,6 +194,10 @@ func (e Encoder[T]) EncodeFlags(in Input) ([]ukhelp.OutputFlag[T], error) {
 	super := e.super()
 
 	for _, spec := range in.MetaReference().Spec.Flags {
+		if spec.Hidden {
+			continue
+		}
+
 		info, err := in.MetaInfo(spec.FieldIndex)
 		if err != nil {
 			return nil, err
@@ -199,10 +208,24 @@ func (e Encoder[T]) EncodeFlags(in Input) ([]ukhelp.OutputFlag[T], error) {
 			return nil, err
 		}
 
-		names := slices.Clone(spec.Names)
+		names := spec.Current()
 		super.SortFlagNames(names)
 
-		item := ukhelp.OutputFlag[T]{Description: description, Names: names}
+		def, err := super.EncodeDefault(in, spec.FieldIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		item := ukhelp.OutputFlag[T]{
+			Description: description,
+			Choices:     spec.Choices,
+			Default:     def,
+			Env:         spec.Env,
+			Names:       names,
+			Required:    spec.Required,
+			Type:        spec.FieldType.String(),
+		}
+
 		list = append(list, item)
 	}
 
@@ -210,6 +233,10 @@ func (e Encoder[T]) EncodeFlags(in Input) ([]ukhelp.OutputFlag[T], error) {
 	return list, nil
 }
 
+func (e Encoder[T]) EncodeGroups(in Input) ([]ukhelp.OutputGroup, error) {
+	return e.super().EncodeGroups(in)
+}
+
 func (e Encoder[T]) EncodeArguments(in Input) ([]ukhelp.OutputArgument[T], error) {
 	var list []ukhelp.OutputArgument[T]
 
@@ -226,7 +253,19 @@ func (e Encoder[T]) EncodeArguments(in Input) ([]ukhelp.OutputArgument[T], error
 			return nil, err
 		}
 
-		item := ukhelp.OutputArgument[T]{Description: description, Position: spec.Position}
+		def, err := super.EncodeDefault(in, spec.FieldIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		item := ukhelp.OutputArgument[T]{
+			Description: description,
+			Default:     def,
+			Position:    spec.Position,
+			Required:    spec.Required,
+			Type:        spec.FieldType.String(),
+		}
+
 		list = append(list, item)
 	}
 
@@ -2,11 +2,21 @@ package ukhelp
 
 import (
 	"context"
+	"slices"
+	"strings"
 
+	"github.com/oligarch316/ukase/internal/ierror"
 	"github.com/oligarch316/ukase/ukcli"
 	"github.com/oligarch316/ukase/ukmeta"
 )
 
+const formatText = "text"
+
+type Params struct {
+	Format string   `ukflag:"format"`
+	Target []string `ukarg:":"`
+}
+
 type Builder struct{ config Config }
 
 func NewBuilder(opts ...Option) Builder {
@@ -19,8 +29,19 @@ func (b Builder) Auto(name string) func(ukcli.State) ukcli.State {
 	return builder.Auto(name)
 }
 
-func (b Builder) Build(refTarget ...string) (ukcli.Exec[struct{}], any) {
+func (b Builder) Build(refTarget ...string) (ukcli.Exec[Params], any) {
 	exec := func(ctx context.Context, in ukcli.Input) error {
+		var params Params
+
+		if err := in.Decode(&params); err != nil {
+			return err
+		}
+
+		render, err := b.loadRender(params.Format)
+		if err != nil {
+			return err
+		}
+
 		helpInput, err := b.config.Prepare(in, refTarget)
 		if err != nil {
 			return err
@@ -31,8 +52,27 @@ func (b Builder) Build(refTarget ...string) (ukcli.Exec[struct{}], any) {
 			return err
 		}
 
-		return b.config.Render(ctx, helpData)
+		return render(ctx, helpData)
 	}
 
 	return exec, b.config.Info
 }
+
+func (b Builder) loadRender(format string) (func(context.Context, any) error, error) {
+	if format == "" || format == formatText {
+		return b.config.Render, nil
+	}
+
+	if render, ok := b.config.Formats[format]; ok {
+		return render, nil
+	}
+
+	formats := []string{formatText}
+	for name := range b.config.Formats {
+		formats = append(formats, name)
+	}
+
+	slices.Sort(formats)
+
+	return nil, ierror.FmtU("unsupported format '%s' (expected one of: %s)", format, strings.Join(formats, ", "))
+}
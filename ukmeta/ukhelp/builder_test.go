@@ -0,0 +1,191 @@
+package ukhelp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/oligarch316/ukase/internal/itest"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type paramsRoot struct {
+	Verbose bool `ukflag:"v verbose"`
+}
+
+type paramsDeploy struct {
+	Region string   `ukflag:"region" ukreq:""`
+	Mode   string   `ukflag:"mode" ukdefault:"fast"`
+	Old    string   `ukflag:"zone" ukhide:""`
+	Hosts  []string `ukarg:":"`
+}
+
+type cliOption func(*ukcli.Config)
+
+func (o cliOption) UkaseApplyCLI(c *ukcli.Config) { o(c) }
+
+type helpOption func(*ukhelp.Config)
+
+func (o helpOption) UkaseApplyHelp(c *ukhelp.Config) { o(c) }
+
+func handleNoop[Params any](context.Context, Params) error { return nil }
+
+// Execute the given values against a small application, returning output
+// rendered as text and as JSON respectively
+func runHelp(values ...string) (string, string, error) {
+	var text, data bytes.Buffer
+
+	render := helpOption(func(c *ukhelp.Config) {
+		renderText := ukhelp.TemplateRenderer{Name: "help", Text: "{{ .Command.Target }}\n", Out: &text}
+		renderJSON := ukhelp.NewJSONRenderer(&data, ukinfo.Render)
+
+		c.Render = func(_ context.Context, v any) error { return renderText.Render(v) }
+		c.Formats = map[string]func(context.Context, any) error{
+			"json": func(_ context.Context, v any) error { return renderJSON.Render(v) },
+		}
+	})
+
+	helpAuto := ukhelp.NewBuilder(render).Auto("help")
+	middleware := cliOption(func(c *ukcli.Config) { c.Middleware = append(c.Middleware, helpAuto) })
+
+	runtime := ukcli.NewRuntime(middleware)
+	runtime.Add(
+		ukcli.NewHandler(handleNoop[paramsRoot]).Bind(),
+		ukcli.NewHandler(handleNoop[paramsDeploy]).Bind("deploy"),
+		ukcli.NewInfo(ukinfo.Description{Short: "Deploy things", Long: "Deploy things, at length"}).Bind("deploy"),
+	)
+
+	err := runtime.Execute(context.Background(), append([]string{"./bin/my-tool"}, values...))
+	return text.String(), data.String(), err
+}
+
+// =============================================================================
+// JSON
+// =============================================================================
+
+type jsonDocument struct {
+	Command struct {
+		Path        []string `json:"path"`
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		Exec        bool     `json:"exec"`
+	} `json:"command"`
+
+	Subcommands []struct {
+		Name    string   `json:"name"`
+		Aliases []string `json:"aliases"`
+		Summary string   `json:"summary"`
+	} `json:"subcommands"`
+
+	Flags []struct {
+		Names    []string `json:"names"`
+		Type     string   `json:"type"`
+		Default  string   `json:"default"`
+		Required bool     `json:"required"`
+		Choices  []string `json:"choices"`
+	} `json:"flags"`
+
+	Arguments []struct {
+		Position string `json:"position"`
+		Type     string `json:"type"`
+	} `json:"arguments"`
+}
+
+func TestRenderJSON(t *testing.T) {
+	text, data, err := runHelp("deploy", "help", "--format", "json")
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(text, ""))
+
+	var actual jsonDocument
+	assert.NilError(t, json.Unmarshal([]byte(data), &actual))
+
+	assert.Check(t, cmp.DeepEqual(actual.Command.Path, []string{"./bin/my-tool", "deploy"}))
+	assert.Check(t, cmp.Equal(actual.Command.Summary, "Deploy things"))
+	assert.Check(t, cmp.Equal(actual.Command.Description, "Deploy things, at length"))
+	assert.Check(t, actual.Command.Exec)
+
+	// Hidden flags are omitted
+	assert.Assert(t, cmp.Len(actual.Subcommands, 1))
+	assert.Check(t, cmp.Equal(actual.Subcommands[0].Name, "help"))
+	assert.Check(t, cmp.DeepEqual(actual.Subcommands[0].Aliases, []string{}))
+
+	assert.Assert(t, cmp.Len(actual.Flags, 2))
+
+	flags := make(map[string]int)
+	for i, flag := range actual.Flags {
+		assert.Assert(t, cmp.Len(flag.Names, 1))
+		assert.Check(t, flag.Choices != nil, "null choices for flag '%s'", flag.Names[0])
+		flags[flag.Names[0]] = i
+	}
+
+	region, mode := actual.Flags[flags["region"]], actual.Flags[flags["mode"]]
+	assert.Check(t, cmp.Equal(region.Type, "string"))
+	assert.Check(t, region.Required)
+	assert.Check(t, cmp.Equal(mode.Default, "fast"))
+	assert.Check(t, !mode.Required)
+
+	assert.Assert(t, cmp.Len(actual.Arguments, 1))
+	assert.Check(t, cmp.Equal(actual.Arguments[0].Position, ":"))
+	assert.Check(t, cmp.Equal(actual.Arguments[0].Type, "[]string"))
+}
+
+func TestRenderJSONRoot(t *testing.T) {
+	_, data, err := runHelp("help", "--format", "json")
+	assert.NilError(t, err)
+
+	var actual jsonDocument
+	assert.NilError(t, json.Unmarshal([]byte(data), &actual))
+
+	var names []string
+	for _, subcommand := range actual.Subcommands {
+		names = append(names, subcommand.Name)
+	}
+
+	assert.Check(t, cmp.DeepEqual(actual.Command.Path, []string{"./bin/my-tool"}))
+	assert.Check(t, cmp.DeepEqual(names, []string{"deploy", "help"}))
+}
+
+func TestRenderFormatText(t *testing.T) {
+	text, data, err := runHelp("deploy", "help", "--format", "text")
+	assert.NilError(t, err)
+	assert.Check(t, cmp.Equal(text, "[deploy]\n"))
+	assert.Check(t, cmp.Equal(data, ""))
+}
+
+func TestRenderFormatUnknown(t *testing.T) {
+	_, _, err := runHelp("deploy", "help", "--format", "yaml")
+	assert.Check(t, itest.CmpErrorIsU(err))
+	assert.Check(t, cmp.ErrorContains(err, "unsupported format 'yaml' (expected one of: json, text)"))
+}
+
+func TestRenderFormatFlag(t *testing.T) {
+	// • Expect› The help flag is not added to the user's flag namespace
+	_, _, err := runHelp("deploy", "--region", "x", "--format", "json")
+	assert.Check(t, itest.CmpErrorIsU(err))
+
+	// • Expect› The user may define a flag of the same name
+	type paramsFormat struct {
+		Format bool `ukflag:"format"`
+	}
+
+	helpAuto := ukhelp.NewBuilder().Auto("help")
+	middleware := cliOption(func(c *ukcli.Config) { c.Middleware = append(c.Middleware, helpAuto) })
+
+	runtime := ukcli.NewRuntime(middleware)
+	runtime.Add(
+		ukcli.NewHandler(handleNoop[struct{}]).Bind(),
+		ukcli.NewHandler(handleNoop[paramsFormat]).Bind("deploy"),
+	)
+
+	err = runtime.Execute(context.Background(), []string{"./bin/my-tool", "deploy", "--format"})
+	assert.NilError(t, err)
+}
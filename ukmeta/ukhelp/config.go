@@ -22,6 +22,10 @@ type Config struct {
 	Prepare func(in ukcli.Input, refTarget []string) (ukmeta.Input, error)
 	Encode  func(in ukmeta.Input) (any, error)
 	Render  func(ctx context.Context, data any) error
+
+	// Alternate renderers selected by name via the `--format` flag, the
+	// default format "text" always selects Render
+	Formats map[string]func(ctx context.Context, data any) error
 }
 
 func newConfig(opts []Option) Config {
@@ -41,6 +45,7 @@ var cfgDefault = Config{
 	Prepare: cfgPrepare,
 	Encode:  cfgEncode,
 	Render:  cfgRender,
+	Formats: map[string]func(context.Context, any) error{"json": cfgRenderJSON},
 }
 
 func cfgPrepare(in ukcli.Input, refTarget []string) (ukmeta.Input, error) {
@@ -61,17 +66,15 @@ func cfgEncode(in ukmeta.Input) (any, error) {
 }
 
 func cfgRender(ctx context.Context, data any) error {
-	return cfgTemplate.Render(data)
+	renderer := NewTerminalRenderer(os.Stdout, ukinfo.Render)
+	return renderer.Render(data)
 }
 
-var cfgTemplate = TemplateRenderer{
-	Name:  "help",
-	Text:  cfgTemplateText,
-	Out:   os.Stdout,
-	Funcs: cfgRenderFuncs.Map(),
+func cfgRenderJSON(ctx context.Context, data any) error {
+	return cfgJSON.Render(data)
 }
 
+var cfgJSON = NewJSONRenderer(os.Stdout, ukinfo.Render)
+
 //go:embed render.tmpl
 var cfgTemplateText string
-
-var cfgRenderFuncs = NewRenderFuncs(ukinfo.Render)
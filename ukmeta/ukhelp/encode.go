@@ -2,7 +2,12 @@ package ukhelp
 
 import (
 	"cmp"
+	"encoding"
+	"fmt"
+	"reflect"
 	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/oligarch316/ukase/ukcore/ukspec"
 	"github.com/oligarch316/ukase/ukmeta"
@@ -34,6 +39,11 @@ func (e Encoder[T]) Encode(in ukmeta.Input) (Output[T], error) {
 		return Output[T]{}, err
 	}
 
+	groups, err := e.EncodeGroups(in)
+	if err != nil {
+		return Output[T]{}, err
+	}
+
 	arguments, err := e.EncodeArguments(in)
 	if err != nil {
 		return Output[T]{}, err
@@ -43,6 +53,7 @@ func (e Encoder[T]) Encode(in ukmeta.Input) (Output[T], error) {
 		Command:     command,
 		Subcommands: subcommands,
 		Flags:       flags,
+		Groups:      groups,
 		Arguments:   arguments,
 	}
 
@@ -64,12 +75,20 @@ func (e Encoder[T]) EncodeSubcommands(in ukmeta.Input) ([]OutputSubcommand[T], e
 	var list []OutputSubcommand[T]
 
 	for name, meta := range in.MetaReference().Children() {
+		if meta.Hidden {
+			continue
+		}
+
 		description, err := e(meta.Info)
 		if err != nil {
 			return nil, err
 		}
 
-		item := OutputSubcommand[T]{Description: description, Name: name}
+		// Deprecated aliases are omitted from `meta.Aliases`
+		aliases := slices.Clone(meta.Aliases)
+		slices.Sort(aliases)
+
+		item := OutputSubcommand[T]{Description: description, Name: name, Aliases: aliases}
 		list = append(list, item)
 	}
 
@@ -81,10 +100,28 @@ func (e Encoder[T]) EncodeFlags(in ukmeta.Input) ([]OutputFlag[T], error) {
 	var list []OutputFlag[T]
 
 	for _, spec := range in.MetaReference().Spec.Flags {
-		names := slices.Clone(spec.Names)
+		if spec.Hidden {
+			continue
+		}
+
+		// Deprecated spellings are accepted but not advertised
+		names := spec.Current()
 		e.SortFlagNames(names)
 
-		item := OutputFlag[T]{Names: names}
+		def, err := e.EncodeDefault(in, spec.FieldIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		item := OutputFlag[T]{
+			Choices:  spec.Choices,
+			Default:  def,
+			Env:      spec.Env,
+			Names:    names,
+			Required: spec.Required,
+			Type:     spec.FieldType.String(),
+		}
+
 		list = append(list, item)
 	}
 
@@ -92,11 +129,53 @@ func (e Encoder[T]) EncodeFlags(in ukmeta.Input) ([]OutputFlag[T], error) {
 	return list, nil
 }
 
+func (e Encoder[T]) EncodeGroups(in ukmeta.Input) ([]OutputGroup, error) {
+	var list []OutputGroup
+
+	spec := in.MetaReference().Spec
+
+	for _, group := range spec.Groups {
+		var names ukspec.FlagNames
+
+		for _, name := range group.Names {
+			// Reference each member by its longest current name, as in usage
+			flagSpec, _ := spec.LookupFlag(name)
+			if flagSpec.Hidden {
+				continue
+			}
+
+			current := flagSpec.Current()
+			e.SortFlagNames(current)
+			names = append(names, current[len(current)-1])
+		}
+
+		// Groups of fewer than 2 visible members convey nothing
+		if len(names) < 2 {
+			continue
+		}
+
+		list = append(list, OutputGroup{Kind: group.Kind, Names: names})
+	}
+
+	return list, nil
+}
+
 func (e Encoder[T]) EncodeArguments(in ukmeta.Input) ([]OutputArgument[T], error) {
 	var list []OutputArgument[T]
 
 	for _, spec := range in.MetaReference().Spec.Arguments {
-		item := OutputArgument[T]{Position: spec.Position}
+		def, err := e.EncodeDefault(in, spec.FieldIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		item := OutputArgument[T]{
+			Default:  def,
+			Position: spec.Position,
+			Required: spec.Required,
+			Type:     spec.FieldType.String(),
+		}
+
 		list = append(list, item)
 	}
 
@@ -104,6 +183,59 @@ func (e Encoder[T]) EncodeArguments(in ukmeta.Input) ([]OutputArgument[T], error
 	return list, nil
 }
 
+// Defaults are those of initialized parameters, ie. from `ukdefault` tags,
+// `UkaseInit()` methods and registered rules. Zero values are omitted.
+func (e Encoder[T]) EncodeDefault(in ukmeta.Input, index []int) (string, error) {
+	def, err := in.MetaDefault(index)
+	if err != nil {
+		return "", err
+	}
+
+	return formatDefault(reflect.ValueOf(def))
+}
+
+// Format pointers by their target, slices and arrays as comma separated lists
+// and all else via encoding.TextMarshaler if implemented or else fmt
+func formatDefault(val reflect.Value) (string, error) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return "", nil
+		}
+		val = val.Elem()
+	}
+
+	if !val.IsValid() || val.IsZero() {
+		return "", nil
+	}
+
+	if marshaler, ok := val.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	if val.CanAddr() {
+		if marshaler, ok := val.Addr().Interface().(encoding.TextMarshaler); ok {
+			text, err := marshaler.MarshalText()
+			return string(text), err
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Array, reflect.Slice:
+		items := make([]string, val.Len())
+		for i := range items {
+			item, err := formatDefault(val.Index(i))
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return fmt.Sprint(val.Interface()), nil
+	}
+}
+
 // =============================================================================
 // Sort
 // =============================================================================
@@ -139,7 +271,7 @@ func (e Encoder[T]) SortArguments(list []OutputArgument[T]) {
 }
 
 func (e Encoder[T]) SortFlagNames(list ukspec.FlagNames) {
-	// Sort by length of name
-	compare := func(a, b string) int { return len(a) - len(b) }
+	// Sort by length of name, in runes to match the parser's short names
+	compare := func(a, b string) int { return utf8.RuneCountInString(a) - utf8.RuneCountInString(b) }
 	slices.SortFunc(list, compare)
 }
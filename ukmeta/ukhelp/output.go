@@ -6,6 +6,7 @@ type Output[T any] struct {
 	Command     OutputCommand[T]
 	Subcommands []OutputSubcommand[T]
 	Flags       []OutputFlag[T]
+	Groups      []OutputGroup
 	Arguments   []OutputArgument[T]
 }
 
@@ -19,14 +20,28 @@ type OutputCommand[T any] struct {
 type OutputSubcommand[T any] struct {
 	Description T
 	Name        string
+	Aliases     []string
 }
 
 type OutputFlag[T any] struct {
 	Description T
+	Choices     []string
+	Default     string
+	Env         ukspec.FlagEnv
 	Names       ukspec.FlagNames
+	Required    bool
+	Type        string
+}
+
+type OutputGroup struct {
+	Kind  ukspec.FlagGroupKind
+	Names ukspec.FlagNames
 }
 
 type OutputArgument[T any] struct {
 	Description T
+	Default     string
 	Position    ukspec.ArgumentPosition
+	Required    bool
+	Type        string
 }
@@ -1,9 +1,18 @@
 package ukhelp
 
 import (
+	"encoding/json"
+	"fmt"
 	"io"
+	"maps"
+	"os"
+	"slices"
 	"strings"
 	"text/template"
+	"unicode/utf8"
+
+	"github.com/oligarch316/ukase/internal/ispec"
+	"github.com/oligarch316/ukase/ukcore/ukspec"
 )
 
 // =============================================================================
@@ -38,6 +47,146 @@ func (tr TemplateRenderer) build() (*template.Template, error) {
 		Parse(tr.Text)
 }
 
+// -----------------------------------------------------------------------------
+// ❭ Terminal
+// › Default help template, wrapped and colored to suit the given output
+// -----------------------------------------------------------------------------
+
+func NewTerminalRenderer[T any](out *os.File, renderDescription func(T, bool) string) TemplateRenderer {
+	funcs := NewRenderFuncs(renderDescription).Map()
+	maps.Copy(funcs, DetectTerminal(out).Map())
+
+	return TemplateRenderer{Name: "help", Text: cfgTemplateText, Out: out, Funcs: funcs}
+}
+
+// =============================================================================
+// JSON
+// › Stable, machine readable form of Output[T] for indexing and tooling
+// › Field names and order are fixed, lists are never null
+// =============================================================================
+
+type JSONRenderer[T any] struct {
+	Out      io.Writer
+	Describe func(description T, long bool) string
+}
+
+func NewJSONRenderer[T any](out io.Writer, renderDescription func(T, bool) string) JSONRenderer[T] {
+	return JSONRenderer[T]{Out: out, Describe: renderDescription}
+}
+
+func (jr JSONRenderer[T]) Render(data any) error {
+	output, ok := data.(Output[T])
+	if !ok {
+		return fmt.Errorf("[TODO JSONRenderer.Render] unexpected help data type '%T'", data)
+	}
+
+	encoder := json.NewEncoder(jr.Out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jr.document(output))
+}
+
+type jsonDocument struct {
+	Command     jsonCommand      `json:"command"`
+	Subcommands []jsonSubcommand `json:"subcommands"`
+	Flags       []jsonFlag       `json:"flags"`
+	Groups      []jsonGroup      `json:"groups"`
+	Arguments   []jsonArgument   `json:"arguments"`
+}
+
+type jsonCommand struct {
+	Path        []string `json:"path"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Exec        bool     `json:"exec"`
+}
+
+type jsonSubcommand struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases"`
+	Summary string   `json:"summary"`
+}
+
+type jsonFlag struct {
+	Names       []string `json:"names"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default"`
+	Required    bool     `json:"required"`
+	Choices     []string `json:"choices"`
+	Env         string   `json:"env"`
+	Description string   `json:"description"`
+}
+
+type jsonGroup struct {
+	Kind  string   `json:"kind"`
+	Names []string `json:"names"`
+}
+
+type jsonArgument struct {
+	Position    string `json:"position"`
+	Type        string `json:"type"`
+	Default     string `json:"default"`
+	Required    bool   `json:"required"`
+	Description string `json:"description"`
+}
+
+func (jr JSONRenderer[T]) document(o Output[T]) jsonDocument {
+	doc := jsonDocument{
+		Command: jsonCommand{
+			Path:        append([]string{o.Command.Program}, o.Command.Target...),
+			Summary:     jr.Describe(o.Command.Description, false),
+			Description: jr.Describe(o.Command.Description, true),
+			Exec:        o.Command.Exec,
+		},
+		Subcommands: make([]jsonSubcommand, len(o.Subcommands)),
+		Flags:       make([]jsonFlag, len(o.Flags)),
+		Groups:      make([]jsonGroup, len(o.Groups)),
+		Arguments:   make([]jsonArgument, len(o.Arguments)),
+	}
+
+	for i, item := range o.Subcommands {
+		doc.Subcommands[i] = jsonSubcommand{
+			Name:    item.Name,
+			Aliases: jsonList(item.Aliases),
+			Summary: jr.Describe(item.Description, false),
+		}
+	}
+
+	for i, item := range o.Flags {
+		doc.Flags[i] = jsonFlag{
+			Names:       jsonList(item.Names),
+			Type:        item.Type,
+			Default:     item.Default,
+			Required:    item.Required,
+			Choices:     jsonList(item.Choices),
+			Env:         item.Env.String(),
+			Description: jr.Describe(item.Description, false),
+		}
+	}
+
+	for i, item := range o.Groups {
+		doc.Groups[i] = jsonGroup{Kind: item.Kind.String(), Names: jsonList(item.Names)}
+	}
+
+	for i, item := range o.Arguments {
+		doc.Arguments[i] = jsonArgument{
+			Position:    item.Position.String(),
+			Type:        item.Type,
+			Default:     item.Default,
+			Required:    item.Required,
+			Description: jr.Describe(item.Description, false),
+		}
+	}
+
+	return doc
+}
+
+func jsonList[S ~[]string](list S) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
 // =============================================================================
 // Template Functions
 // =============================================================================
@@ -48,8 +197,12 @@ func NewRenderFuncs[T any](renderDescription func(T, bool) string) RenderFuncs[T
 	return RenderFuncs[T](renderDescription)
 }
 
+// Map the render functions by name, including the terminal functions of a
+// plain (uncolored) terminal of default width
 func (rf RenderFuncs[T]) Map() template.FuncMap {
-	return template.FuncMap{
+	funcs := Terminal{Width: terminalWidthDefault}.Map()
+
+	maps.Copy(funcs, template.FuncMap{
 		"describeCommand":    rf.command,
 		"describeSubcommand": rf.subcommand,
 		"describeFlag":       rf.flag,
@@ -64,11 +217,20 @@ func (rf RenderFuncs[T]) Map() template.FuncMap {
 		"labelSubcommand": rf.labelSubcommand,
 		"labelFlag":       rf.labelFlag,
 		"labelArgument":   rf.labelArgument,
+		"labelChoices":    rf.labelChoices,
+
+		"usageFlag":     rf.usageFlag,
+		"usageGroup":    rf.usageGroup,
+		"usageArgument": rf.usageArgument,
 
 		"maxSubcommand": rf.maxSubcommand,
 		"maxFlag":       rf.maxFlag,
 		"maxArgument":   rf.maxArgument,
-	}
+
+		"joinText": rf.joinText,
+	})
+
+	return funcs
 }
 
 // -----------------------------------------------------------------------------
@@ -99,7 +261,7 @@ func (r RenderFuncs[T]) maxArgument(o Output[T]) int   { return rMax(o.Arguments
 
 func rMax[S ~[]E, E any](list S, labelF func(E) string) (max int) {
 	for _, item := range list {
-		if candidate := len(labelF(item)); candidate > max {
+		if candidate := utf8.RuneCountInString(labelF(item)); candidate > max {
 			max = candidate
 		}
 	}
@@ -116,19 +278,16 @@ func (RenderFuncs[T]) labelCommand(o OutputCommand[T]) string {
 }
 
 func (RenderFuncs[T]) labelSubcommand(o OutputSubcommand[T]) string {
-	return o.Name
+	items := append([]string{o.Name}, o.Aliases...)
+	return strings.Join(items, ", ")
 }
 
 func (RenderFuncs[T]) labelFlag(o OutputFlag[T]) string {
 	var items []string
 
 	for _, name := range o.Names {
-		switch len(name) {
-		case 0:
-		case 1:
-			items = append(items, "-"+name)
-		default:
-			items = append(items, "--"+name)
+		if name != "" {
+			items = append(items, ispec.LabelFlag(name))
 		}
 	}
 
@@ -139,3 +298,44 @@ func (RenderFuncs[T]) labelArgument(o OutputArgument[T]) string {
 	// TODO: More human friendly display than half open range???
 	return strings.Replace(o.Position.String(), ":", "...", 1)
 }
+
+func (RenderFuncs[T]) labelChoices(choices []string) string {
+	return "{" + strings.Join(choices, "|") + "}"
+}
+
+// -----------------------------------------------------------------------------
+// ❭ Usage
+// -----------------------------------------------------------------------------
+
+func (RenderFuncs[T]) usageFlag(o OutputFlag[T]) string {
+	// Prefer the last (longest) name
+	return ispec.LabelFlag(o.Names[len(o.Names)-1])
+}
+
+func (RenderFuncs[T]) usageGroup(o OutputGroup) string {
+	items := make([]string, len(o.Names))
+	for i, name := range o.Names {
+		items[i] = ispec.LabelFlag(name)
+	}
+
+	switch o.Kind {
+	case ukspec.FlagGroupExclusive:
+		return "(" + strings.Join(items, " | ") + ")"
+	default:
+		return "[" + strings.Join(items, " ") + "]"
+	}
+}
+
+func (r RenderFuncs[T]) usageArgument(o OutputArgument[T]) string {
+	return "<" + r.labelArgument(o) + ">"
+}
+
+// -----------------------------------------------------------------------------
+// ❭ Text
+// -----------------------------------------------------------------------------
+
+// Join the non-empty parts with single spaces
+func (RenderFuncs[T]) joinText(parts ...string) string {
+	parts = slices.DeleteFunc(parts, func(part string) bool { return part == "" })
+	return strings.Join(parts, " ")
+}
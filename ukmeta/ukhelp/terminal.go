@@ -0,0 +1,133 @@
+package ukhelp
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// =============================================================================
+// Terminal
+// › Width and color capabilities of the terminal that help is written to
+// =============================================================================
+
+const (
+	terminalWidthDefault = 80
+	terminalWidthMinimum = 20
+
+	ansiReset   = "\x1b[0m"
+	ansiHeading = "\x1b[1m"
+	ansiLabel   = "\x1b[36m"
+)
+
+type Terminal struct {
+	Width int
+	Color bool
+}
+
+// Detect the width of the given output, falling back to `$COLUMNS` when the
+// output is not a terminal. Color is enabled only for terminal output, and
+// never when `$NO_COLOR` is set.
+func DetectTerminal(out *os.File) Terminal {
+	tty := isTerminal(out)
+	term := Terminal{Width: terminalWidthDefault, Color: tty}
+
+	if width, ok := terminalWidth(out); ok {
+		term.Width = width
+	} else if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		term.Width = width
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		term.Color = false
+	}
+
+	return term
+}
+
+func isTerminal(out *os.File) bool {
+	info, err := out.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// -----------------------------------------------------------------------------
+// ❭ Template Functions
+// -----------------------------------------------------------------------------
+
+func (t Terminal) Map() template.FuncMap {
+	return template.FuncMap{
+		"termWidth":    t.width,
+		"wrap":         t.wrap,
+		"colorHeading": t.colorHeading,
+		"colorLabel":   t.colorLabel,
+	}
+}
+
+func (t Terminal) width() int { return t.Width }
+
+func (t Terminal) colorHeading(s string) string { return t.color(ansiHeading, s) }
+func (t Terminal) colorLabel(s string) string   { return t.color(ansiLabel, s) }
+
+func (t Terminal) color(code, s string) string {
+	if !t.Color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// Wrap text to the terminal width, assuming the first line begins at the
+// given indent column and indenting all subsequent lines to match. Leading
+// indentation within the text is kept, continuing onto wrapped lines.
+func (t Terminal) wrap(indent int, s string) string {
+	s = strings.TrimRight(strings.TrimLeft(s, "\n"), " \t\n")
+
+	available := t.Width - indent
+	if available < terminalWidthMinimum {
+		// Too narrow to wrap sensibly
+		// ⇒ Leave it to the terminal
+		return s
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		lines = append(lines, wrapParagraph(available, paragraph)...)
+	}
+
+	return strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+func wrapParagraph(width int, paragraph string) []string {
+	prefix := paragraph[:len(paragraph)-len(strings.TrimLeft(paragraph, " \t"))]
+	prefixWidth := utf8.RuneCountInString(prefix)
+
+	var lines []string
+	var line strings.Builder
+	var lineWidth int
+
+	for _, word := range strings.Fields(paragraph) {
+		wordWidth := utf8.RuneCountInString(word)
+
+		if lineWidth > 0 && prefixWidth+lineWidth+1+wordWidth > width {
+			lines = append(lines, prefix+line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+
+		if lineWidth > 0 {
+			line.WriteByte(' ')
+			lineWidth++
+		}
+
+		line.WriteString(word)
+		lineWidth += wordWidth
+	}
+
+	if lineWidth == 0 {
+		// Blank paragraph ⇒ Drop any trailing whitespace
+		return append(lines, "")
+	}
+
+	return append(lines, prefix+line.String())
+}
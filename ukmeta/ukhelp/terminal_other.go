@@ -0,0 +1,7 @@
+//go:build !(linux || darwin || freebsd)
+
+package ukhelp
+
+import "os"
+
+func terminalWidth(out *os.File) (int, bool) { return 0, false }
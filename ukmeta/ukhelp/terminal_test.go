@@ -0,0 +1,184 @@
+package ukhelp_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+func renderTerminal(t *testing.T, term ukhelp.Terminal, text string, data any) string {
+	var out bytes.Buffer
+
+	renderer := ukhelp.TemplateRenderer{Name: "test", Text: text, Out: &out, Funcs: term.Map()}
+	assert.NilError(t, renderer.Render(data))
+
+	return out.String()
+}
+
+// Open a regular file in place of terminal output
+func openNonTerminal(t *testing.T) *os.File {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	assert.NilError(t, err)
+
+	t.Cleanup(func() { file.Close() })
+	return file
+}
+
+// =============================================================================
+// Wrap
+// =============================================================================
+
+func TestWrap(t *testing.T) {
+	term := ukhelp.Terminal{Width: 24}
+	actual := renderTerminal(t, term, "{{ wrap 4 . }}", "one two three four five six seven")
+
+	// • Expect› 20 columns available beyond the indent
+	expected := "one two three four\n    five six seven"
+	assert.Check(t, cmp.Equal(actual, expected))
+}
+
+func TestWrapRuneWidth(t *testing.T) {
+	term := ukhelp.Terminal{Width: 20}
+	actual := renderTerminal(t, term, "{{ wrap 0 . }}", "ééééé ééééé ééééé ééééé")
+
+	// • Expect› Width measured in runes, not bytes
+	expected := "ééééé ééééé ééééé\nééééé"
+	assert.Check(t, cmp.Equal(actual, expected))
+}
+
+func TestWrapIndentation(t *testing.T) {
+	term := ukhelp.Terminal{Width: 24}
+	text := "Example:\n\n    one two three four five\n"
+	actual := renderTerminal(t, term, "{{ wrap 0 . }}", text)
+
+	// • Expect› Leading indentation kept, including on wrapped lines
+	expected := "Example:\n\n    one two three four\n    five"
+	assert.Check(t, cmp.Equal(actual, expected))
+}
+
+func TestWrapNarrow(t *testing.T) {
+	term := ukhelp.Terminal{Width: 24}
+	actual := renderTerminal(t, term, "{{ wrap 10 . }}", "one two three four five six seven")
+
+	// • Expect› Text left unwrapped when too narrow to wrap sensibly
+	assert.Check(t, cmp.Equal(actual, "one two three four five six seven"))
+}
+
+// =============================================================================
+// Color
+// =============================================================================
+
+func TestColor(t *testing.T) {
+	text := "{{ colorHeading . }}|{{ colorLabel . }}"
+
+	color := renderTerminal(t, ukhelp.Terminal{Width: 80, Color: true}, text, "x")
+	assert.Check(t, cmp.Equal(color, "\x1b[1mx\x1b[0m|\x1b[36mx\x1b[0m"))
+
+	plain := renderTerminal(t, ukhelp.Terminal{Width: 80}, text, "x")
+	assert.Check(t, cmp.Equal(plain, "x|x"))
+}
+
+func TestDetectTerminal(t *testing.T) {
+	t.Setenv("COLUMNS", "40")
+	t.Setenv("NO_COLOR", "")
+
+	actual := ukhelp.DetectTerminal(openNonTerminal(t))
+
+	// • Expect› No color and width from $COLUMNS when not a terminal
+	assert.Check(t, cmp.Equal(actual, ukhelp.Terminal{Width: 40, Color: false}))
+}
+
+// =============================================================================
+// Render
+// =============================================================================
+
+// Execute the given values against a small application, returning help
+// rendered by the default terminal renderer to a (non-terminal) file
+func runTerminal(t *testing.T, values ...string) string {
+	t.Setenv("NO_COLOR", "")
+
+	out := openNonTerminal(t)
+
+	render := helpOption(func(c *ukhelp.Config) {
+		renderer := ukhelp.NewTerminalRenderer(out, ukinfo.Render)
+		c.Render = func(_ context.Context, v any) error { return renderer.Render(v) }
+	})
+
+	helpAuto := ukhelp.NewBuilder(render).Auto("help")
+	middleware := cliOption(func(c *ukcli.Config) { c.Middleware = append(c.Middleware, helpAuto) })
+
+	runtime := ukcli.NewRuntime(middleware)
+	runtime.Add(
+		ukcli.NewHandler(handleNoop[paramsDeploy]).Bind("deploy"),
+		ukcli.NewInfo(ukinfo.Description{Short: "Deploy things"}).Bind("deploy"),
+		ukcli.NewHandler(handleNoop[paramsChoose]).Bind("choose"),
+	)
+
+	err := runtime.Execute(context.Background(), append([]string{"./bin/my-tool"}, values...))
+	assert.NilError(t, err)
+
+	data, err := os.ReadFile(out.Name())
+	assert.NilError(t, err)
+
+	return string(data)
+}
+
+type choiceSpeed string
+
+func (choiceSpeed) UkaseChoices() []string { return []string{"fast", "slow"} }
+
+type paramsChoose struct {
+	Speed choiceSpeed `ukflag:"speed"`
+	Level string      `ukflag:"level" ukdefault:"low"`
+	File  string      `ukarg:"0" ukdefault:"a.txt"`
+}
+
+func TestRenderTerminalNoColor(t *testing.T) {
+	actual := runTerminal(t, "deploy", "help")
+
+	assert.Check(t, cmp.Contains(actual, "--region"))
+	assert.Check(t, !strings.Contains(actual, "\x1b["), "escape codes in output:\n%s", actual)
+}
+
+func TestRenderTerminalUndescribed(t *testing.T) {
+	actual := runTerminal(t, "choose", "help")
+
+	// • Expect› No separator precedes the first non-empty part
+	assert.Check(t, cmp.Contains(actual, "  --speed  {fast|slow}\n"))
+	assert.Check(t, cmp.Contains(actual, "  --level  (default: low)\n"))
+	assert.Check(t, cmp.Contains(actual, "  0...1  (default: a.txt)\n"))
+}
+
+func TestRenderFuncsDefaultTemplate(t *testing.T) {
+	// • Expect› Terminal functions available without a terminal renderer
+	funcs := ukhelp.NewRenderFuncs(ukinfo.Render).Map()
+
+	for _, name := range []string{"termWidth", "wrap", "colorHeading", "colorLabel"} {
+		_, ok := funcs[name]
+		assert.Check(t, ok, "missing template function '%s'", name)
+	}
+
+	var out bytes.Buffer
+	renderer := ukhelp.TemplateRenderer{
+		Name:  "custom",
+		Text:  "{{ colorHeading \"Usage\" }} {{ wrap 2 . }}",
+		Out:   &out,
+		Funcs: funcs,
+	}
+
+	assert.NilError(t, renderer.Render("text"))
+	assert.Check(t, cmp.Equal(out.String(), "Usage text"))
+}
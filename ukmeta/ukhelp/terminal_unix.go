@@ -0,0 +1,26 @@
+//go:build linux || darwin || freebsd
+
+package ukhelp
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func terminalWidth(out *os.File) (int, bool) {
+	var size struct{ rows, cols, xpixel, ypixel uint16 }
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		out.Fd(),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(&size)),
+	)
+
+	if errno != 0 || size.cols == 0 {
+		return 0, false
+	}
+
+	return int(size.cols), true
+}
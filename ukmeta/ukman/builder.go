@@ -0,0 +1,156 @@
+package ukman
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/oligarch316/ukase/internal/ierror"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+type Params struct {
+	Dir string `ukarg:"0" ukdefault:"."`
+}
+
+type Builder struct{ config Config }
+
+func NewBuilder(opts ...Option) Builder {
+	config := newConfig(opts)
+	return Builder{config: config}
+}
+
+// =============================================================================
+// Export
+// › Writes one page per exported command, named by program and target path,
+//   eg. "tool-copy.1"
+// =============================================================================
+
+func (b Builder) Export(in ukcli.Input, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	export := func(node ukmeta.ExportNode) error {
+		page, err := b.encodePage(node)
+		if err != nil {
+			return err
+		}
+
+		return b.writePage(dir, page)
+	}
+
+	return ukmeta.Export(in, export)
+}
+
+func (b Builder) encodePage(node ukmeta.ExportNode) (Page, error) {
+	helpData, err := b.config.Encode(node.Input)
+	if err != nil {
+		return Page{}, err
+	}
+
+	help, ok := helpData.(ukhelp.Output[ukinfo.Description])
+	if !ok {
+		return Page{}, ierror.FmtD("unexpected help data type '%T'", helpData)
+	}
+
+	// Subcommands not exported (meta commands) have no page to refer to
+	help.Subcommands = slices.DeleteFunc(help.Subcommands, func(item ukhelp.OutputSubcommand[ukinfo.Description]) bool {
+		return !slices.Contains(node.Children, item.Name)
+	})
+
+	program := help.Command.Program
+	var seeAlso []string
+
+	if len(node.Path) > 0 {
+		seeAlso = append(seeAlso, pageName(program, node.Path[:len(node.Path)-1]))
+	}
+
+	for _, name := range node.Children {
+		seeAlso = append(seeAlso, pageName(program, append(slices.Clip(node.Path), name)))
+	}
+
+	page := Page{
+		Name:    pageName(program, node.Path),
+		Section: b.config.Section,
+		Help:    help,
+		SeeAlso: seeAlso,
+	}
+
+	return page, nil
+}
+
+func (b Builder) writePage(dir string, page Page) (err error) {
+	file, err := os.Create(filepath.Join(dir, page.Name+"."+page.Section))
+	if err != nil {
+		return err
+	}
+
+	defer func() { err = errors.Join(err, file.Close()) }()
+
+	renderer := b.config.Template
+	renderer.Out = file
+	return renderer.Render(page)
+}
+
+func pageName(program string, path []string) string {
+	return strings.Join(append([]string{program}, path...), "-")
+}
+
+// =============================================================================
+// Build
+// =============================================================================
+
+func (b Builder) Build() (ukcli.Exec[Params], any) {
+	exec := func(ctx context.Context, in ukcli.Input) error {
+		var params Params
+
+		if err := in.Initialize(&params); err != nil {
+			return err
+		}
+
+		if err := in.Decode(&params); err != nil {
+			return err
+		}
+
+		return b.Export(in, params.Dir)
+	}
+
+	return exec, b.config.Info
+}
+
+func (b Builder) Bind(target ...string) ukcli.Directive {
+	return ukcli.NewDirective(func(s ukcli.State) error {
+		exec, info := b.Build()
+
+		if err := s.RegisterMeta(target...); err != nil {
+			return err
+		}
+
+		if err := exec.Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		if err := ukcli.NewInfo(info).Bind(target...).UkaseRegister(s); err != nil {
+			return err
+		}
+
+		return s.RegisterHidden(target...)
+	})
+}
+
+// =============================================================================
+// Auto
+// › Registers the (hidden) man page command beneath the root upon first
+//   registration
+// =============================================================================
+
+func (b Builder) Auto(name string) func(ukcli.State) ukcli.State {
+	return ukmeta.AutoOnce(b.Bind(name))
+}
@@ -0,0 +1,106 @@
+package ukman_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+	"github.com/oligarch316/ukase/ukmeta/ukman"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/assert/cmp"
+)
+
+// =============================================================================
+// Utilities
+// =============================================================================
+
+type paramsDeploy struct {
+	Region string `ukflag:"region"`
+}
+
+type cliOption func(*ukcli.Config)
+
+func (o cliOption) UkaseApplyCLI(c *ukcli.Config) { o(c) }
+
+type manOption func(*ukman.Config)
+
+func (o manOption) UkaseApplyMan(c *ukman.Config) { o(c) }
+
+func handleNoop[Params any](context.Context, Params) error { return nil }
+
+// Encode as the default encoder does, adding per-flag descriptions as those
+// generated by ukgen would
+func encodeDescribed(in ukmeta.Input) (any, error) {
+	output, err := ukhelp.NewEncoder(ukinfo.Encode).Encode(in)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, flag := range output.Flags {
+		output.Flags[i].Description = ukinfo.Description{Short: "Describe " + flag.Names[0]}
+	}
+
+	return output, nil
+}
+
+func runExport(t *testing.T, opts ...ukman.Option) string {
+	dir := t.TempDir()
+
+	helpAuto := ukhelp.NewBuilder().Auto("help")
+	middleware := cliOption(func(c *ukcli.Config) { c.Middleware = append(c.Middleware, helpAuto) })
+
+	runtime := ukcli.NewRuntime(middleware)
+	runtime.Add(
+		ukcli.NewHandler(handleNoop[struct{}]).Bind(),
+		ukcli.NewHandler(handleNoop[paramsDeploy]).Bind("deploy"),
+		ukman.NewBuilder(opts...).Bind("man"),
+	)
+
+	err := runtime.Execute(context.Background(), []string{"./bin/my-tool", "man", dir})
+	assert.NilError(t, err)
+
+	return dir
+}
+
+func readPage(t *testing.T, dir, name string) string {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	assert.NilError(t, err)
+	return string(data)
+}
+
+// =============================================================================
+// Export
+// =============================================================================
+
+func TestExport(t *testing.T) {
+	dir := runExport(t)
+
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	// • Expect› Meta (help) and hidden (man) commands have no pages
+	assert.Check(t, cmp.DeepEqual(names, []string{"my-tool-deploy.1", "my-tool.1"}))
+
+	root := readPage(t, dir, "my-tool.1")
+	assert.Check(t, cmp.Contains(root, "\\fBdeploy\\fR"))
+	assert.Check(t, !strings.Contains(root, "help"))
+	assert.Check(t, cmp.Contains(root, ".SH SEE ALSO\n\\fBmy\\-tool\\-deploy\\fR(1)\n"))
+}
+
+func TestExportEncode(t *testing.T) {
+	dir := runExport(t, manOption(func(c *ukman.Config) { c.Encode = encodeDescribed }))
+
+	page := readPage(t, dir, "my-tool-deploy.1")
+	assert.Check(t, cmp.Contains(page, "\\fB\\-\\-region\\fR\nDescribe region\n"))
+}
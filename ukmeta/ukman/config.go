@@ -0,0 +1,93 @@
+package ukman
+
+import (
+	_ "embed"
+
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+// =============================================================================
+// Config
+// =============================================================================
+
+type Option interface{ UkaseApplyMan(*Config) }
+
+type Config struct {
+	// TODO: Document
+	Info any
+
+	// TODO: Document
+	Section string
+
+	// TODO: Document
+	Encode func(in ukmeta.Input) (any, error)
+
+	// TODO: Document
+	Template ukhelp.TemplateRenderer
+}
+
+func newConfig(opts []Option) Config {
+	config := cfgDefault
+	for _, opt := range opts {
+		opt.UkaseApplyMan(&config)
+	}
+	return config
+}
+
+// =============================================================================
+// Defaults
+// =============================================================================
+
+var cfgDefault = Config{
+	Info:     "Generate man pages",
+	Section:  "1",
+	Encode:   cfgEncode,
+	Template: cfgTemplate,
+}
+
+func cfgEncode(in ukmeta.Input) (any, error) {
+	encoder := ukhelp.NewEncoder(ukinfo.Encode)
+	return encoder.Encode(in)
+}
+
+var cfgTemplate = ukhelp.TemplateRenderer{
+	Name:  "man",
+	Text:  cfgTemplateText,
+	Out:   os.Stdout,
+	Funcs: cfgRenderFuncs(),
+}
+
+//go:embed render.tmpl
+var cfgTemplateText string
+
+func cfgRenderFuncs() template.FuncMap {
+	funcs := ukhelp.NewRenderFuncs(ukinfo.Render).Map()
+	funcs["roff"] = renderRoff
+	funcs["upper"] = strings.ToUpper
+	return funcs
+}
+
+// -----------------------------------------------------------------------------
+// ❭ Render Functions
+// -----------------------------------------------------------------------------
+
+var renderRoffReplacer = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// Escape text for roff, such that no line may be mistaken for a request
+func renderRoff(s string) string {
+	lines := strings.Split(renderRoffReplacer.Replace(s), "\n")
+
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,13 @@
+package ukman
+
+import (
+	"github.com/oligarch316/ukase/ukcli/ukinfo"
+	"github.com/oligarch316/ukase/ukmeta/ukhelp"
+)
+
+type Page struct {
+	Name    string
+	Section string
+	Help    ukhelp.Output[ukinfo.Description]
+	SeeAlso []string
+}
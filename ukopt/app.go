@@ -17,5 +17,8 @@ func (o App) UkaseApplyApp(c *ukase.Config) { o(c) }
 // =============================================================================
 
 func AppHelpCommand(name string) App      { return func(c *ukase.Config) { c.HelpCommand = name } }
+func AppCompCommand(name string) App      { return func(c *ukase.Config) { c.CompCommand = name } }
+func AppManCommand(name string) App       { return func(c *ukase.Config) { c.ManCommand = name } }
+func AppDocCommand(name string) App       { return func(c *ukase.Config) { c.DocCommand = name } }
 func AppInputProgram(name string) App     { return func(c *ukase.Config) { c.InputProgram = name } }
 func AppInputArguments(args []string) App { return func(c *ukase.Config) { c.InputArguments = args } }
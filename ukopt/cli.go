@@ -3,6 +3,7 @@ package ukopt
 import (
 	"github.com/oligarch316/ukase"
 	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcore/uksrc"
 )
 
 // =============================================================================
@@ -26,3 +27,7 @@ func (o CLI) UkaseApplyApp(c *ukase.Config) { c.CLI = append(c.CLI, o) }
 func CLIMiddleware(middleware func(ukcli.State) ukcli.State) CLI {
 	return func(c *ukcli.Config) { c.Middleware = append(c.Middleware, middleware) }
 }
+
+func CLISource(sources ...uksrc.Source) CLI {
+	return func(c *ukcli.Config) { c.Source = append(c.Source, sources...) }
+}
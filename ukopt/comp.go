@@ -0,0 +1,28 @@
+package ukopt
+
+import (
+	"github.com/oligarch316/ukase"
+	"github.com/oligarch316/ukase/ukmeta/ukcomp"
+)
+
+// =============================================================================
+// General
+// =============================================================================
+
+var (
+	_ ukcomp.Option = Comp(nil)
+	_ ukase.Option  = Comp(nil)
+)
+
+type Comp func(*ukcomp.Config)
+
+func (o Comp) UkaseApplyComp(c *ukcomp.Config) { o(c) }
+func (o Comp) UkaseApplyApp(c *ukase.Config)   { c.Comp = append(c.Comp, o) }
+
+// =============================================================================
+// Specific
+// =============================================================================
+
+func CompInfo(info any) Comp {
+	return func(c *ukcomp.Config) { c.Info = info }
+}
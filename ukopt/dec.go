@@ -4,6 +4,7 @@ import (
 	"github.com/oligarch316/ukase"
 	"github.com/oligarch316/ukase/ukcli"
 	"github.com/oligarch316/ukase/ukcore/ukdec"
+	"github.com/oligarch316/ukase/ukcore/ukinit"
 )
 
 // =============================================================================
@@ -11,17 +12,48 @@ import (
 // =============================================================================
 
 var (
-	_ ukdec.Option = Dec(nil)
-	_ ukcli.Option = Dec(nil)
-	_ ukase.Option = Dec(nil)
+	_ ukdec.Option  = Dec(nil)
+	_ ukinit.Option = Dec(nil)
+	_ ukcli.Option  = Dec(nil)
+	_ ukase.Option  = Dec(nil)
 )
 
 type Dec func(*ukdec.Config)
 
-func (o Dec) UkaseApplyDec(c *ukdec.Config) { o(c) }
-func (o Dec) UkaseApplyCLI(c *ukcli.Config) { c.Decode = append(c.Decode, o) }
-func (o Dec) UkaseApplyApp(c *ukase.Config) { c.CLI = append(c.CLI, o) }
+func (o Dec) UkaseApplyDec(c *ukdec.Config)   { o(c) }
+func (o Dec) UkaseApplyInit(c *ukinit.Config) { c.Decode = append(c.Decode, o) }
+func (o Dec) UkaseApplyApp(c *ukase.Config)   { c.CLI = append(c.CLI, o) }
+
+// Tag defaults are decoded during initialization, as is user input
+func (o Dec) UkaseApplyCLI(c *ukcli.Config) {
+	c.Decode = append(c.Decode, o)
+	c.Init = append(c.Init, o)
+}
 
 // =============================================================================
 // Specific
 // =============================================================================
+
+func DecEnvLookup(lookup func(name string) (value string, exists bool)) Dec {
+	return func(c *ukdec.Config) { c.EnvLookup = lookup }
+}
+
+func DecCheckRequired(check bool) Dec {
+	return func(c *ukdec.Config) { c.CheckRequired = check }
+}
+
+func DecCheckGroups(check bool) Dec {
+	return func(c *ukdec.Config) { c.CheckGroups = check }
+}
+
+func DecWarn(warn func(ukdec.Warning)) Dec {
+	return func(c *ukdec.Config) { c.Warn = warn }
+}
+
+func DecTimeLayout(layout string) Dec {
+	return func(c *ukdec.Config) { c.TimeLayout = layout }
+}
+
+func DecRegister[T any](parse func(string) (T, error)) Dec {
+	return ukdec.RegisterDecoder(parse).UkaseApplyDec
+}
@@ -0,0 +1,37 @@
+package ukopt
+
+import (
+	"github.com/oligarch316/ukase"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukdoc"
+)
+
+// =============================================================================
+// General
+// =============================================================================
+
+var (
+	_ ukdoc.Option = Doc(nil)
+	_ ukase.Option = Doc(nil)
+)
+
+type Doc func(*ukdoc.Config)
+
+func (o Doc) UkaseApplyDoc(c *ukdoc.Config) { o(c) }
+func (o Doc) UkaseApplyApp(c *ukase.Config) { c.Doc = append(c.Doc, o) }
+
+// =============================================================================
+// Specific
+// =============================================================================
+
+func DocInfo(info any) Doc {
+	return func(c *ukdoc.Config) { c.Info = info }
+}
+
+func DocIndex(name string) Doc {
+	return func(c *ukdoc.Config) { c.Index = name }
+}
+
+func DocEncode(encode func(in ukmeta.Input) (any, error)) Doc {
+	return func(c *ukdoc.Config) { c.Encode = encode }
+}
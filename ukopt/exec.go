@@ -1,6 +1,8 @@
 package ukopt
 
 import (
+	"context"
+
 	"github.com/oligarch316/ukase"
 	"github.com/oligarch316/ukase/ukcli"
 	"github.com/oligarch316/ukase/ukcore/ukexec"
@@ -25,3 +27,11 @@ func (o Exec) UkaseApplyApp(c *ukase.Config)   { c.CLI = append(c.CLI, o) }
 // =============================================================================
 // Specific
 // =============================================================================
+
+func ExecInterspersed(interspersed bool) Exec {
+	return func(c *ukexec.Config) { c.Interspersed = interspersed }
+}
+
+func ExecWarnDeprecated(warn func(ctx context.Context, name, canonical string)) Exec {
+	return func(c *ukexec.Config) { c.WarnDeprecated = warn }
+}
@@ -1,6 +1,9 @@
 package ukopt
 
 import (
+	"context"
+	"maps"
+
 	"github.com/oligarch316/ukase"
 	"github.com/oligarch316/ukase/ukmeta"
 	"github.com/oligarch316/ukase/ukmeta/ukhelp"
@@ -31,3 +34,20 @@ func HelpInfo(info any) Help {
 func HelpEncode(encode func(in ukmeta.Input) (any, error)) Help {
 	return func(c *ukhelp.Config) { c.Encode = encode }
 }
+
+func HelpRender(render func(ctx context.Context, data any) error) Help {
+	return func(c *ukhelp.Config) { c.Render = render }
+}
+
+func HelpFormat(name string, render func(ctx context.Context, data any) error) Help {
+	return func(c *ukhelp.Config) {
+		// Clone prior to insertion, the default config map is shared
+		formats := maps.Clone(c.Formats)
+		if formats == nil {
+			formats = make(map[string]func(context.Context, any) error)
+		}
+
+		formats[name] = render
+		c.Formats = formats
+	}
+}
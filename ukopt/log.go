@@ -9,32 +9,35 @@ import (
 	"github.com/oligarch316/ukase/ukcore/ukexec"
 	"github.com/oligarch316/ukase/ukcore/ukinit"
 	"github.com/oligarch316/ukase/ukcore/ukspec"
+	"github.com/oligarch316/ukase/ukcore/ukvalid"
 	"github.com/oligarch316/ukase/ukmeta/ukgen"
 	"github.com/oligarch316/ukase/ukmeta/ukhelp"
 )
 
 var (
-	_ ukase.Option  = Log{}
-	_ ukcli.Option  = Log{}
-	_ ukdec.Option  = Log{}
-	_ ukexec.Option = Log{}
-	_ ukgen.Option  = Log{}
-	_ ukhelp.Option = Log{}
-	_ ukinit.Option = Log{}
-	_ ukspec.Option = Log{}
-	_ ukase.Option  = Log{}
+	_ ukase.Option   = Log{}
+	_ ukcli.Option   = Log{}
+	_ ukdec.Option   = Log{}
+	_ ukexec.Option  = Log{}
+	_ ukgen.Option   = Log{}
+	_ ukhelp.Option  = Log{}
+	_ ukinit.Option  = Log{}
+	_ ukspec.Option  = Log{}
+	_ ukvalid.Option = Log{}
+	_ ukase.Option   = Log{}
 )
 
 const logKey = "ukase"
 
 type Log struct{ *slog.Logger }
 
-func (o Log) UkaseApplyDec(c *ukdec.Config)   { /* TODO */ }
-func (o Log) UkaseApplyExec(c *ukexec.Config) { c.Log = o.with("exec") }
-func (o Log) UkaseApplyGen(c *ukgen.Config)   { c.Log = o.with("gen") }
-func (o Log) UkaseApplyHelp(c *ukhelp.Config) { /* TODO */ }
-func (o Log) UkaseApplyInit(c *ukinit.Config) { /* TODO */ }
-func (o Log) UkaseApplySpec(c *ukspec.Config) { /* TODO */ }
+func (o Log) UkaseApplyDec(c *ukdec.Config)     { /* TODO */ }
+func (o Log) UkaseApplyExec(c *ukexec.Config)   { c.Log = o.with("exec") }
+func (o Log) UkaseApplyGen(c *ukgen.Config)     { c.Log = o.with("gen") }
+func (o Log) UkaseApplyHelp(c *ukhelp.Config)   { /* TODO */ }
+func (o Log) UkaseApplyInit(c *ukinit.Config)   { /* TODO */ }
+func (o Log) UkaseApplySpec(c *ukspec.Config)   { /* TODO */ }
+func (o Log) UkaseApplyValid(c *ukvalid.Config) { c.Log = o.with("valid") }
 
 func (o Log) UkaseApplyCLI(c *ukcli.Config) {
 	c.Log = o.with("cli")
@@ -43,6 +46,7 @@ func (o Log) UkaseApplyCLI(c *ukcli.Config) {
 	c.Decode = append(c.Decode, o)
 	c.Init = append(c.Init, o)
 	c.Spec = append(c.Spec, o)
+	c.Valid = append(c.Valid, o)
 }
 
 func (o Log) UkaseApplyApp(c *ukase.Config) {
@@ -0,0 +1,37 @@
+package ukopt
+
+import (
+	"github.com/oligarch316/ukase"
+	"github.com/oligarch316/ukase/ukmeta"
+	"github.com/oligarch316/ukase/ukmeta/ukman"
+)
+
+// =============================================================================
+// General
+// =============================================================================
+
+var (
+	_ ukman.Option = Man(nil)
+	_ ukase.Option = Man(nil)
+)
+
+type Man func(*ukman.Config)
+
+func (o Man) UkaseApplyMan(c *ukman.Config) { o(c) }
+func (o Man) UkaseApplyApp(c *ukase.Config) { c.Man = append(c.Man, o) }
+
+// =============================================================================
+// Specific
+// =============================================================================
+
+func ManInfo(info any) Man {
+	return func(c *ukman.Config) { c.Info = info }
+}
+
+func ManSection(section string) Man {
+	return func(c *ukman.Config) { c.Section = section }
+}
+
+func ManEncode(encode func(in ukmeta.Input) (any, error)) Man {
+	return func(c *ukman.Config) { c.Encode = encode }
+}
@@ -0,0 +1,23 @@
+package ukopt
+
+import (
+	"github.com/oligarch316/ukase"
+	"github.com/oligarch316/ukase/ukcli"
+	"github.com/oligarch316/ukase/ukcore/ukvalid"
+)
+
+// =============================================================================
+// General
+// =============================================================================
+
+var (
+	_ ukvalid.Option = Valid(nil)
+	_ ukcli.Option   = Valid(nil)
+	_ ukase.Option   = Valid(nil)
+)
+
+type Valid func(*ukvalid.Config)
+
+func (o Valid) UkaseApplyValid(c *ukvalid.Config) { o(c) }
+func (o Valid) UkaseApplyCLI(c *ukcli.Config)     { c.Valid = append(c.Valid, o) }
+func (o Valid) UkaseApplyApp(c *ukase.Config)     { c.CLI = append(c.CLI, o) }